@@ -0,0 +1,127 @@
+package uulid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// AtomicGenerator implements a lock free UUID generator based on the ULID spec.
+// The generated UULID is monotonically increased for calls within the same millisecond.
+//
+// The Generator state is an immutable snapshot of the millisecond and the 80 bit entropy,
+// replaced atomically on each call. The entropy for each millisecond is derived from the
+// seed and incremented within the millisecond, with the same 2^80 entropy space of the
+// Generator before ErrMonotonicOverflow. Each UULID costs a small state allocation.
+type AtomicGenerator struct {
+	state atomic.Value // *atomicState
+	seed  uint64
+}
+
+// atomicState is the immutable monotonic state of an AtomicGenerator.
+type atomicState struct {
+	ms uint64
+	hi uint16
+	lo uint64
+}
+
+// NewAtomicGenerator is like NewAtomicGeneratorWithSeed()
+// but uses a secure random seed from crypto/rand.
+func NewAtomicGenerator() (r *AtomicGenerator, err error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return NewAtomicGeneratorWithSeed(binary.BigEndian.Uint64(b)), nil
+}
+
+// NewAtomicGeneratorWithSeed creates a new lock free UULID generator.
+// It uses the given seed for deriving the entropy of each millisecond.
+//
+// Ensure that a good random seed is used or use NewAtomicGenerator()
+// which provides a secure seed from crypto/rand.
+func NewAtomicGeneratorWithSeed(seed uint64) (r *AtomicGenerator) {
+	return &AtomicGenerator{seed: seed}
+}
+
+// New creates a UULID with the current system time.
+func (r *AtomicGenerator) New() (id UULID, err error) {
+	for {
+		// the state is loaded before reading the clock, so a concurrent
+		// call can never have stored a later millisecond from the clock
+		state := r.state.Load()
+
+		ms, err := timestamp(time.Now())
+		if err != nil {
+			return id, err
+		}
+
+		if ok, err := r.next(&id, state, ms); ok || err != nil {
+			return id, err
+		}
+	}
+}
+
+// NewAt creates a UULID with the given time.
+// Calls within the same millisecond of the previous call are monotonically increased.
+func (r *AtomicGenerator) NewAt(t time.Time) (id UULID, err error) {
+	ms, err := timestamp(t)
+	if err != nil {
+		return id, err
+	}
+
+	for {
+		state := r.state.Load()
+		if ok, err := r.next(&id, state, ms); ok || err != nil {
+			return id, err
+		}
+	}
+}
+
+// next tries to advance the Generator from the given state to the given millisecond.
+// It returns false without an error if the state was concurrently updated.
+func (r *AtomicGenerator) next(id *UULID, state interface{}, ms uint64) (ok bool, err error) {
+	var last atomicState
+	if s, _ := state.(*atomicState); s != nil {
+		last = *s
+	}
+
+	n := &atomicState{ms: ms}
+
+	switch {
+	case ms < last.ms:
+		return false, ErrSmallTime
+	case ms > last.ms:
+		n.hi, n.lo = r.entropy(ms)
+	default:
+		var carry uint64
+		n.lo, carry = bits.Add64(last.lo, 1, 0)
+		if n.hi = last.hi + uint16(carry); n.hi == 0 && carry == 1 {
+			return false, ErrMonotonicOverflow
+		}
+	}
+
+	if !r.state.CompareAndSwap(state, n) {
+		return false, nil
+	}
+
+	_ = id.SetTimestamp(ms)
+	binary.BigEndian.PutUint16(id[6:8], n.hi)
+	binary.BigEndian.PutUint64(id[8:], n.lo)
+	return true, nil
+}
+
+// entropy derives the base entropy for the given millisecond from the seed.
+// The two halves are mixed from distinct inputs, 2*ms and 2*ms+1, so the entropy
+// of a millisecond never shares an input with the entropy of another one.
+func (r *AtomicGenerator) entropy(ms uint64) (hi uint16, lo uint64) {
+	s := r.seed + 2*ms*0xa0761d6478bd642f
+	h, l := bits.Mul64(s^0xe7037ed1a0b428db, s)
+	hi = uint16(h ^ l)
+
+	s += 0xa0761d6478bd642f
+	h, l = bits.Mul64(s^0xe7037ed1a0b428db, s)
+	return hi, h ^ l
+}
@@ -0,0 +1,158 @@
+package uulid_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestAtomicGenerator_New(t *testing.T) {
+	r, err := uulid.NewAtomicGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	id, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.IsZero() {
+		t.Error("non-initialized uulid")
+	}
+}
+
+func TestAtomicGenerator_NewAt(t *testing.T) {
+	r := uulid.NewAtomicGeneratorWithSeed(1)
+	tm := uulid.Time(timestamp)
+
+	prev, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	for i := 0; i < 0xFFFF; i++ {
+		cur, err := r.NewAt(tm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cur.Timestamp() != timestamp {
+			t.Fatalf("timestamp error, expected: %d, got: %d", timestamp, cur.Timestamp())
+		}
+
+		if prev.Compare(cur) != -1 {
+			t.Fatalf("compare error, expected: %d, got: %d", -1, prev.Compare(cur))
+		}
+		prev = cur
+	}
+
+	if _, err = r.NewAt(tm.Add(-time.Millisecond)); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %s instead", err)
+	}
+
+	if _, err = r.NewAt(uulid.MaxTime().Add(time.Millisecond)); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %s instead", err)
+	}
+
+	cur, err := r.NewAt(tm.Add(time.Millisecond))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if prev.Compare(cur) != -1 {
+		t.Errorf("compare error, expected: %d, got: %d", -1, prev.Compare(cur))
+	}
+}
+
+func TestAtomicGenerator_Entropy(t *testing.T) {
+	r := uulid.NewAtomicGeneratorWithSeed(1)
+
+	prev, err := r.NewAt(uulid.Time(timestamp))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for ms := timestamp + 1; ms < timestamp+1024; ms++ {
+		cur, err := r.NewAt(uulid.Time(ms))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(cur[6:8], prev[14:16]) {
+			t.Fatalf("entropy error, hi of ms %d equals the low bits of ms %d: %x", ms, ms-1, cur[6:8])
+		}
+		prev = cur
+	}
+}
+
+func TestAtomicGenerator_ErrMonotonicOverflow(t *testing.T) {
+	r := uulid.NewAtomicGeneratorWithSeed(1)
+	tm := uulid.Time(timestamp)
+	uulid.SetAtomicGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFE)
+
+	if _, err := r.NewAt(tm); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := r.NewAt(tm); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %s instead", err)
+	}
+
+	// the state must not be modified by the overflow
+	if _, err := r.NewAt(tm); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %s instead", err)
+	}
+
+	if _, err := r.NewAt(tm.Add(time.Millisecond)); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkAtomicGenerator_SeqSafety(b *testing.B) {
+	r, err := uulid.NewAtomicGenerator()
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.BinarySize)
+
+	b.RunParallel(func(pb *testing.PB) {
+		prev, err := r.New()
+		if err != nil {
+			b.Error(err)
+		}
+
+		for pb.Next() {
+			cur, err := r.New()
+			if err != nil {
+				b.Error(err)
+			}
+
+			if prev.Compare(cur) != -1 {
+				b.Error(prev.Compare(cur), prev.Time(), cur.Time())
+			}
+
+			prev = cur
+		}
+	})
+}
+
+func BenchmarkAtomicGenerator_NewConcurrent(b *testing.B) {
+	r, err := uulid.NewAtomicGenerator()
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.BinarySize)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = r.New()
+		}
+	})
+}
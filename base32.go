@@ -0,0 +1,108 @@
+package uulid
+
+const (
+	// Base32EncodedSize is the length of the Crockford's Base32 encoded UULID.
+	Base32EncodedSize = 26
+
+	// base32Alphabet is the Crockford's Base32 alphabet used by the ULID spec.
+	// It is in ASCII order, so the encoding sorts in the same order as the binary.
+	base32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// base32Dec maps a byte to its Crockford's Base32 value or 0xFF if invalid.
+// Lower case letters are accepted and the ambiguous I, L and O are aliased
+// to 1, 1 and 0 respectively.
+var base32Dec = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xFF
+	}
+
+	for i := 0; i < len(base32Alphabet); i++ {
+		c := base32Alphabet[i]
+		t[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			t[c+'a'-'A'] = byte(i)
+		}
+	}
+
+	t['I'], t['i'] = 1, 1
+	t['L'], t['l'] = 1, 1
+	t['O'], t['o'] = 0, 0
+
+	return t
+}()
+
+// Base32 returns the canonical 26 characters Crockford's Base32 encoded ULID.
+// The encoding sorts lexicographically in the same order as the binary UULIDs,
+// making it a compact alternative to String for sortable keys and indexes.
+func (id UULID) Base32() (s string) {
+	var b [Base32EncodedSize]byte
+	_ = id.MarshalBase32To(b[:])
+	return string(b[:])
+}
+
+// MarshalBase32To writes the Crockford's Base32 encoding of the UULID to the given buffer.
+// ErrBufferSize is returned when the len(dst) != Base32EncodedSize.
+func (id UULID) MarshalBase32To(dst []byte) (err error) {
+	if len(dst) != Base32EncodedSize {
+		return ErrBufferSize
+	}
+
+	hi := uint64(id[0])<<56 | uint64(id[1])<<48 | uint64(id[2])<<40 | uint64(id[3])<<32 |
+		uint64(id[4])<<24 | uint64(id[5])<<16 | uint64(id[6])<<8 | uint64(id[7])
+	lo := uint64(id[8])<<56 | uint64(id[9])<<48 | uint64(id[10])<<40 | uint64(id[11])<<32 |
+		uint64(id[12])<<24 | uint64(id[13])<<16 | uint64(id[14])<<8 | uint64(id[15])
+
+	// emit 5 bits at a time from the least significant end of the 128 bit value
+	for i := Base32EncodedSize - 1; i >= 0; i-- {
+		dst[i] = base32Alphabet[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return nil
+}
+
+// ParseBase32 parses a Crockford's Base32 encoded UULID, returning an error in case of failure.
+// Errors are returned as a *ParseError wrapping the cause of the failure:
+//
+// ErrDataSize is returned if the length is different from Base32EncodedSize.
+//
+// ErrInvalidChar is returned if the data contains characters outside of the Crockford's Base32 alphabet.
+//
+// ErrBigTime is returned if the first character overflows the 128 bits of an UULID.
+func ParseBase32(data []byte) (id UULID, err error) {
+	err = parseBase32(data, &id)
+	return id, err
+}
+
+func parseBase32(data []byte, id *UULID) (err error) {
+	if len(data) != Base32EncodedSize {
+		return &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
+	}
+
+	// the leading character can only carry the 3 most significant bits
+	if v := base32Dec[data[0]]; v == 0xFF {
+		return &ParseError{Err: ErrInvalidChar, Len: len(data), Pos: 0}
+	} else if v > 7 {
+		return &ParseError{Err: ErrBigTime, Len: len(data), Pos: 0}
+	}
+
+	var hi, lo uint64
+	for i, c := range data {
+		v := base32Dec[c]
+		if v == 0xFF {
+			return &ParseError{Err: ErrInvalidChar, Len: len(data), Pos: i}
+		}
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+
+	for i := 0; i < 8; i++ {
+		id[i] = byte(hi >> (56 - 8*i))
+		id[i+8] = byte(lo >> (56 - 8*i))
+	}
+
+	return nil
+}
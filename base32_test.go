@@ -0,0 +1,136 @@
+package uulid_test
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+var (
+	// Base32 encoding of the encoded UULID test vector.
+	encodedBase32 = "01F2H897NFPFKG6VAV3EFKSNTK"
+)
+
+func TestUULID_Base32(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.Base32() != encodedBase32 {
+		t.Errorf("encode error, expected: %s, got: %s", encodedBase32, id.Base32())
+	}
+
+	buf := make([]byte, 6)
+	if err = id.MarshalBase32To(buf); err != uulid.ErrBufferSize {
+		t.Errorf("expected ErrBufferSize, got: %s", err)
+	}
+}
+
+func TestParseBase32(t *testing.T) {
+	id, err := uulid.ParseBase32([]byte(encodedBase32))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.String() != string(encoded) {
+		t.Errorf("parse error, expected: %s, got: %s", string(encoded), id.String())
+	}
+
+	if id.Timestamp() != timestamp {
+		t.Errorf("time parse error, expected: %d, got: %d", timestamp, id.Timestamp())
+	}
+
+	// lower case and ambiguous characters
+	id2, err := uulid.ParseBase32([]byte("o1f2h897nfpfkg6vav3efksntk"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.Compare(id2) != 0 {
+		t.Errorf("parse error, expected: %s, got: %s", id.String(), id2.String())
+	}
+
+	id3, err := uulid.ParseBase32([]byte("01F8MECHZX3TBDSZ7XRADM79XE"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id3.Base32() != "01F8MECHZX3TBDSZ7XRADM79XE" {
+		t.Errorf("round trip error, expected: %s, got: %s", "01F8MECHZX3TBDSZ7XRADM79XE", id3.Base32())
+	}
+
+	if _, err = uulid.ParseBase32([]byte("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")); err != nil {
+		t.Error(err)
+	}
+
+	if _, err = uulid.ParseBase32([]byte("8ZZZZZZZZZZZZZZZZZZZZZZZZZ")); !errors.Is(err, uulid.ErrBigTime) {
+		t.Errorf("expected ErrBigTime, got: %s", err)
+	}
+
+	if _, err = uulid.ParseBase32([]byte("01F2H897NFPFKG6VAV3EFKSNTU")); !errors.Is(err, uulid.ErrInvalidChar) {
+		t.Errorf("expected ErrInvalidChar, got: %s", err)
+	}
+
+	if _, err = uulid.ParseBase32(encoded); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got: %s", err)
+	}
+}
+
+func BenchmarkParseBase32(b *testing.B) {
+	buf := []byte(encodedBase32)
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.Base32EncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = uulid.ParseBase32(buf)
+	}
+}
+
+func TestFromULIDBytes(t *testing.T) {
+	// ulid.MustParse("01F8MECHZX3TBDSZ7XRADM79XE") from github.com/oklog/ulid
+	u := [16]byte{0x01, 0x7a, 0x28, 0xe6, 0x47, 0xfd, 0x1e, 0x96, 0xdc, 0xfc, 0xfd, 0xc2, 0x9b, 0x43, 0xa7, 0xae}
+
+	id := uulid.FromULIDBytes(u)
+	if id.Base32() != "01F8MECHZX3TBDSZ7XRADM79XE" {
+		t.Errorf("conversion error, expected: %s, got: %s", "01F8MECHZX3TBDSZ7XRADM79XE", id.Base32())
+	}
+
+	if id.Timestamp() != 1624183818237 {
+		t.Errorf("timestamp error, expected: %d, got: %d", 1624183818237, id.Timestamp())
+	}
+
+	if id.ULIDBytes() != u {
+		t.Errorf("conversion error, expected: %v, got: %v", u, id.ULIDBytes())
+	}
+}
+
+func TestUULID_Base32SortOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	ids := make([]uulid.UULID, 4096)
+	for i := range ids {
+		_, _ = rng.Read(ids[i][:])
+		// share prefixes to exercise the ordering of the later characters
+		if i > 0 && i%2 == 0 {
+			copy(ids[i][:rng.Intn(uulid.BinarySize)], ids[i-1][:])
+		}
+	}
+	ids = append(ids, uulid.Min, uulid.Max)
+
+	bin := append([]uulid.UULID(nil), ids...)
+	sort.Slice(bin, func(i, j int) bool { return bin[i].Compare(bin[j]) < 0 })
+
+	str := append([]uulid.UULID(nil), ids...)
+	sort.Slice(str, func(i, j int) bool { return str[i].Base32() < str[j].Base32() })
+
+	for i := range bin {
+		if bin[i].Compare(str[i]) != 0 {
+			t.Fatalf("sort order error at %d, expected: %s, got: %s", i, bin[i].Base32(), str[i].Base32())
+		}
+	}
+}
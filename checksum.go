@@ -0,0 +1,62 @@
+package uulid
+
+import "errors"
+
+const (
+	// CheckEncodedSize is the length of the UULID encoded with a check character.
+	CheckEncodedSize = HexEncodedSize + 1
+
+	// checkAlphabet is the ISO 7064 MOD 37-2 check character alphabet.
+	checkAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ*"
+)
+
+// StringWithCheck returns the canonical 36 characters UULID encoding followed by an
+// ISO 7064 MOD 37-2 check character computed over its hex digits, which detects all
+// single character substitutions and adjacent transpositions when parsed by ParseWithCheck.
+func (id UULID) StringWithCheck() (s string) {
+	var b [CheckEncodedSize]byte
+	_ = id.MarshalTextTo(b[:HexEncodedSize])
+	b[HexEncodedSize] = checkAlphabet[id.checksum()]
+	return string(b[:])
+}
+
+// ParseWithCheck parses an UULID encoded by StringWithCheck, returning an error in case of failure.
+// Errors are returned as a *ParseError wrapping the cause of the failure:
+//
+// ErrDataSize is returned if the length is different from CheckEncodedSize.
+//
+// ErrChecksum is returned if the check character doesn't match the parsed UULID.
+func ParseWithCheck(data []byte) (id UULID, err error) {
+	if len(data) != CheckEncodedSize {
+		return id, &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
+	}
+
+	if err = parse(data[:HexEncodedSize], &id); err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pe.Len = len(data)
+		}
+		return id, err
+	}
+
+	c := data[HexEncodedSize]
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	if c != checkAlphabet[id.checksum()] {
+		return UULID{}, &ParseError{Err: ErrChecksum, Len: len(data), Pos: HexEncodedSize}
+	}
+
+	return id, nil
+}
+
+// checksum computes the ISO 7064 MOD 37-2 check value over the 32 hex digits of the UULID.
+func (id UULID) checksum() (c byte) {
+	var p uint
+	for _, b := range id {
+		p = (p + uint(b>>4)) * 2 % 37
+		p = (p + uint(b&0x0F)) * 2 % 37
+	}
+	return byte((38 - p) % 37)
+}
@@ -0,0 +1,77 @@
+package uulid_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestUULID_StringWithCheck(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := id.StringWithCheck()
+	if len(s) != uulid.CheckEncodedSize || s[:uulid.HexEncodedSize] != string(encoded) {
+		t.Errorf("encoding error, expected prefix: %s, got: %s", encoded, s)
+	}
+
+	parsed, err := uulid.ParseWithCheck([]byte(s))
+	if err != nil || parsed.Compare(id) != 0 {
+		t.Errorf("parse error, expected: %s, got: %s, error: %s", id, parsed, err)
+	}
+
+	if _, err = uulid.ParseWithCheck(encoded); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got: %s", err)
+	}
+}
+
+func TestParseWithCheck_Errors(t *testing.T) {
+	const digits = "0123456789abcdef"
+
+	for i := 0; i < 100; i++ {
+		id := uulid.MustNew()
+		s := []byte(id.StringWithCheck())
+
+		// every single hex digit substitution is detected
+		for j := 0; j < uulid.HexEncodedSize; j++ {
+			if s[j] == '-' {
+				continue
+			}
+
+			for _, d := range []byte(digits) {
+				if d == s[j] {
+					continue
+				}
+
+				b := append([]byte(nil), s...)
+				b[j] = d
+				if _, err := uulid.ParseWithCheck(b); !errors.Is(err, uulid.ErrChecksum) {
+					t.Fatalf("expected ErrChecksum for %s, got: %v", b, err)
+				}
+			}
+		}
+
+		// every adjacent hex digit transposition is detected
+		for j := 0; j < uulid.HexEncodedSize-1; j++ {
+			if s[j] == '-' || s[j+1] == '-' || s[j] == s[j+1] {
+				continue
+			}
+
+			b := append([]byte(nil), s...)
+			b[j], b[j+1] = b[j+1], b[j]
+			if _, err := uulid.ParseWithCheck(b); !errors.Is(err, uulid.ErrChecksum) {
+				t.Fatalf("expected ErrChecksum for %s, got: %v", b, err)
+			}
+		}
+
+		// the check character is case insensitive
+		b := bytes.ToLower(s)
+		if _, err := uulid.ParseWithCheck(b); err != nil {
+			t.Errorf("parse error for %s: %s", b, err)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/brunotm/uulid"
 )
@@ -14,37 +18,236 @@ const (
 )
 
 var (
-	p     = flag.String("p", "", "parse the given uulid")
+	p     = flag.String("p", "", "parse the given uulid, or one uulid per line from stdin to stdout if -")
+	n     = flag.Int("n", 1, "number of uulids to generate")
+	f     = flag.String("format", "uuid", "output format: uuid, base32 or binary")
 	local = flag.Bool("local", false, "when parsing, show local time instead of UTC")
+	srt   = flag.Bool("sort", false, "sort the uulids read one per line from stdin")
 )
 
 func main() {
 	flag.Parse()
 
-	switch *p {
-	case "":
+	var err error
+	switch {
+	case *f != "uuid" && *f != "base32" && *f != "binary":
+		err = fmt.Errorf("invalid output format: %s", *f)
+
+	case *srt:
+		if isFlagSet("n") || isFlagSet("p") {
+			err = errors.New("-sort cannot be used with -n or -p")
+			break
+		}
+		err = sortStdin()
+
+	case *p == "":
+		err = generate(*n)
+
+	default:
+		if isFlagSet("n") {
+			err = errors.New("-n cannot be used with -p")
+			break
+		}
+		if *p == "-" && *f == "binary" {
+			err = errors.New("-format binary cannot be used with -p -")
+			break
+		}
+		err = parse(*p)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate writes n newline delimited uulids to stdout
+func generate(n int) (err error) {
+	if n < 1 {
+		return fmt.Errorf("invalid number of uulids to generate: %d", n)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	e := uulid.NewEncoder(w)
+
+	for i := 0; i < n; i++ {
 		id, err := uulid.New()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%v\n", err)
-			os.Exit(1)
+			return err
 		}
-		fmt.Fprintf(os.Stdout, "%s\n", id.String())
 
+		if err = write(w, e, id); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// write writes the uulid in the selected output format
+func write(w *bufio.Writer, e *uulid.Encoder, id uulid.UULID) (err error) {
+	switch *f {
+	case "base32":
+		_, err = w.WriteString(id.Base32() + "\n")
+		return err
+	case "binary":
+		return e.EncodeBinary(id)
 	default:
-		id, err := uulid.Parse([]byte(*p))
+		return e.Encode(id)
+	}
+}
+
+// parse prints the time, timestamp and entropy of the given uulid,
+// and re-prints it in the selected output format if set.
+// If s is - the uulids are read one per line from stdin, and the results
+// are written to stdout as lines prefixed by the parsed input line.
+func parse(s string) (err error) {
+	w := bufio.NewWriter(os.Stdout)
+	e := uulid.NewEncoder(w)
+
+	if s != "-" {
+		if err = show(w, e, []byte(s)); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
+	failed, err := scan(func(line []byte) error { return showLine(w, line) })
+	if err != nil {
+		return err
+	}
+
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to parse %d uulids", failed)
+	}
+
+	return nil
+}
+
+// sortStdin reads one uulid per line from stdin and writes
+// them sorted in the selected output format
+func sortStdin() (err error) {
+	var ids []uulid.UULID
+
+	failed, err := scan(func(line []byte) error {
+		id, err := uulid.Parse(line)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%v\n", err)
-			os.Exit(1)
+			return err
 		}
 
-		t := id.Time()
-		if !*local {
-			t = t.UTC()
+		ids = append(ids, id)
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Compare(ids[j]) < 0 })
+
+	w := bufio.NewWriter(os.Stdout)
+	e := uulid.NewEncoder(w)
+	for _, id := range ids {
+		if err = write(w, e, id); err != nil {
+			return err
 		}
+	}
+
+	if err = w.Flush(); err != nil {
+		return err
+	}
 
-		fmt.Fprintf(os.Stderr, "Time: %s,  Timestamp: %d, Entropy: %s\n",
-			t.Format(rfc3339ms),
-			id.Timestamp(),
-			hex.EncodeToString(id.Entropy()))
+	if failed > 0 {
+		return fmt.Errorf("failed to parse %d uulids", failed)
 	}
+
+	return nil
+}
+
+// scan calls fn for each non empty line read from stdin,
+// reporting to stderr and counting the lines for which fn fails
+func scan(fn func(line []byte) error) (failed int, err error) {
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if err = fn(line); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", line, err)
+			failed++
+		}
+	}
+
+	return failed, sc.Err()
+}
+
+// show parses and prints the time, timestamp and entropy of the given uulid
+func show(w *bufio.Writer, e *uulid.Encoder, data []byte) (err error) {
+	id, err := uulid.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	if isFlagSet("format") {
+		if err = write(w, e, id); err != nil {
+			return err
+		}
+
+		// keep the output ordered with the parsed information
+		if err = w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Time: %s,  Timestamp: %d, Entropy: %s\n", info(id)...)
+	return nil
+}
+
+// showLine parses and writes the given uulid line to w followed by a tab, the uulid
+// in the selected output format if set, and its time, timestamp and entropy
+func showLine(w *bufio.Writer, line []byte) (err error) {
+	id, err := uulid.Parse(line)
+	if err != nil {
+		return err
+	}
+
+	_, _ = w.Write(line)
+	_ = w.WriteByte('\t')
+
+	if isFlagSet("format") {
+		s := id.String()
+		if *f == "base32" {
+			s = id.Base32()
+		}
+		_, _ = w.WriteString(s + "\t")
+	}
+
+	_, err = fmt.Fprintf(w, "Time: %s,  Timestamp: %d, Entropy: %s\n", info(id)...)
+	return err
+}
+
+// info returns the time, timestamp and entropy of the uulid for printing
+func info(id uulid.UULID) (a []interface{}) {
+	t := id.TimeUTC()
+	if *local {
+		t = id.Time()
+	}
+
+	return []interface{}{t.Format(rfc3339ms), id.Timestamp(), hex.EncodeToString(id.Entropy())}
+}
+
+// isFlagSet returns true if the named flag was set in the command line
+func isFlagSet(name string) (ok bool) {
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			ok = true
+		}
+	})
+	return ok
 }
@@ -0,0 +1,28 @@
+package uulid_test
+
+import (
+	"fmt"
+
+	"github.com/brunotm/uulid"
+)
+
+func ExampleUULID_Clone() {
+	id := uulid.MustParseString("0178a284-9eaf-b3e7-036d-5b1b9f3cd753")
+
+	// assignments copy the UULID value
+	v := id
+	v[15] = 0x00
+
+	// pointers alias the UULID value
+	p := &id
+	c := p.Clone()
+	p[15] = 0x00
+
+	fmt.Println(v)
+	fmt.Println(id)
+	fmt.Println(c)
+	// Output:
+	// 0178a284-9eaf-b3e7-036d-5b1b9f3cd700
+	// 0178a284-9eaf-b3e7-036d-5b1b9f3cd700
+	// 0178a284-9eaf-b3e7-036d-5b1b9f3cd753
+}
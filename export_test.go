@@ -0,0 +1,14 @@
+package uulid
+
+// SetGeneratorState sets the internal monotonic state of the Generator for testing.
+func SetGeneratorState(r *Generator, ms uint64, hi uint16, lo uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ms, r.hi, r.lo = ms, hi, lo
+}
+
+// SetAtomicGeneratorState sets the internal monotonic state of the AtomicGenerator for testing.
+func SetAtomicGeneratorState(r *AtomicGenerator, ms uint64, hi uint16, lo uint64) {
+	r.state.Store(&atomicState{ms: ms, hi: hi, lo: lo})
+}
@@ -0,0 +1,175 @@
+package uulid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// Format is an UULID encoding format as detected by ParseFormat.
+type Format int
+
+// The UULID encoding formats. The zero Format is not a valid format.
+const (
+	FormatBinary Format = iota + 1 // 16 bytes binary
+	FormatHex                      // 32 characters hex 0178a2849eafb3e7036d5b1b9f3cd753
+	FormatUUID                     // 36 characters canonical 0178a284-9eaf-b3e7-036d-5b1b9f3cd753
+	FormatBase32                   // 26 characters Crockford's Base32 01F2H897NFPFKG6VAV3EFKSNTK
+	FormatBraced                   // 38 characters {0178a284-9eaf-b3e7-036d-5b1b9f3cd753}
+	FormatURN                      // 45 characters urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753
+)
+
+// String returns the name of the Format.
+func (f Format) String() (s string) {
+	switch f {
+	case FormatBinary:
+		return "binary"
+	case FormatHex:
+		return "hex"
+	case FormatUUID:
+		return "uuid"
+	case FormatBase32:
+		return "base32"
+	case FormatBraced:
+		return "braced"
+	case FormatURN:
+		return "urn"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ParseFormat is like Parse but also returns the detected Format of the data,
+// so that UULIDs can be re-encoded in the same format they were received.
+func ParseFormat(data []byte) (id UULID, f Format, err error) {
+	f = format(data)
+	if err = parse(data, &id); err != nil {
+		return UULID{}, 0, err
+	}
+
+	return id, f, nil
+}
+
+// FormatAs returns the UULID encoded in the given Format, so that UULIDs
+// parsed with ParseFormat can be re-encoded in the same format. FormatBinary
+// returns the raw 16 bytes as a string. An empty string is returned for an
+// invalid Format. It is not named Format as that implements fmt.Formatter.
+func (id UULID) FormatAs(f Format) (s string) {
+	var buf [len(urnPrefix) + HexEncodedSize]byte
+	var b []byte
+
+	switch f {
+	case FormatBinary:
+		return string(id[:])
+	case FormatHex:
+		b = buf[:hex.EncodedLen(BinarySize)]
+		hex.Encode(b, id[:])
+	case FormatUUID:
+		b = buf[:HexEncodedSize]
+		_ = id.MarshalTextTo(b)
+	case FormatBase32:
+		b = buf[:Base32EncodedSize]
+		_ = id.MarshalBase32To(b)
+	case FormatBraced:
+		b = buf[:HexEncodedSize+2]
+		b[0], b[HexEncodedSize+1] = '{', '}'
+		_ = id.MarshalTextTo(b[1 : HexEncodedSize+1])
+	case FormatURN:
+		b = buf[:]
+		copy(b, urnPrefix)
+		_ = id.MarshalTextTo(b[len(urnPrefix):])
+	default:
+		return ""
+	}
+
+	return string(b)
+}
+
+// format detects the Format of the data from its length and delimiters.
+func format(data []byte) (f Format) {
+	switch len(data) {
+	case BinarySize:
+		return FormatBinary
+	case 32:
+		return FormatHex
+	case HexEncodedSize:
+		return FormatUUID
+	case Base32EncodedSize:
+		return FormatBase32
+	case HexEncodedSize + 2:
+		if data[0] == '{' && data[len(data)-1] == '}' {
+			return FormatBraced
+		}
+	case len(urnPrefix) + HexEncodedSize:
+		if bytes.EqualFold(data[:len(urnPrefix)], []byte(urnPrefix)) {
+			return FormatURN
+		}
+	}
+	return 0
+}
+
+// Format implements the fmt.Formatter interface.
+//
+//	%s, %v  canonical 36 characters UUID format
+//	%q      quoted canonical UUID format
+//	%x, %X  32 characters lower and upper case hex without dashes
+//	%b      26 characters Crockford's Base32 ULID format
+//
+// The width flag pads the output with spaces, on the left by default
+// or on the right with the - flag.
+func (id UULID) Format(f fmt.State, verb rune) {
+	var buf [HexEncodedSize + 2]byte
+	var b []byte
+
+	switch verb {
+	case 's', 'v':
+		b = buf[:HexEncodedSize]
+		_ = id.MarshalTextTo(b)
+	case 'q':
+		b = buf[:HexEncodedSize+2]
+		b[0], b[HexEncodedSize+1] = '"', '"'
+		_ = id.MarshalTextTo(b[1 : HexEncodedSize+1])
+	case 'x', 'X':
+		b = buf[:hex.EncodedLen(BinarySize)]
+		hex.Encode(b, id[:])
+		if verb == 'X' {
+			toUpper(b)
+		}
+	case 'b':
+		b = buf[:Base32EncodedSize]
+		_ = id.MarshalBase32To(b)
+	default:
+		fmt.Fprintf(f, "%%!%c(uulid.UULID=%s)", verb, id.String())
+		return
+	}
+
+	pad := 0
+	if w, ok := f.Width(); ok && w > len(b) {
+		pad = w - len(b)
+	}
+
+	if !f.Flag('-') {
+		writePadding(f, pad)
+	}
+
+	_, _ = f.Write(b)
+
+	if f.Flag('-') {
+		writePadding(f, pad)
+	}
+}
+
+// toUpper converts the lower case hex characters in b to upper case.
+func toUpper(b []byte) {
+	for i, c := range b {
+		if c >= 'a' && c <= 'f' {
+			b[i] = c - ('a' - 'A')
+		}
+	}
+}
+
+// writePadding writes n spaces to f.
+func writePadding(f fmt.State, n int) {
+	for ; n > 0; n-- {
+		_, _ = f.Write([]byte{' '})
+	}
+}
@@ -0,0 +1,118 @@
+package uulid_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestUULID_Format(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "0178a284-9eaf-b3e7-036d-5b1b9f3cd753"},
+		{"%v", "0178a284-9eaf-b3e7-036d-5b1b9f3cd753"},
+		{"%q", `"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"`},
+		{"%x", "0178a2849eafb3e7036d5b1b9f3cd753"},
+		{"%X", "0178A2849EAFB3E7036D5B1B9F3CD753"},
+		{"%b", encodedBase32},
+		{"%40s|", "    0178a284-9eaf-b3e7-036d-5b1b9f3cd753|"},
+		{"%-40s|", "0178a284-9eaf-b3e7-036d-5b1b9f3cd753    |"},
+		{"%d", "%!d(uulid.UULID=0178a284-9eaf-b3e7-036d-5b1b9f3cd753)"},
+	}
+
+	for _, test := range tests {
+		if s := fmt.Sprintf(test.format, id); s != test.expected {
+			t.Errorf("format error for %s, expected: %s, got: %s", test.format, test.expected, s)
+		}
+	}
+
+	if s := fmt.Sprintf("%x", &id); s != "0178a2849eafb3e7036d5b1b9f3cd753" {
+		t.Errorf("format error for pointer, expected: %s, got: %s", "0178a2849eafb3e7036d5b1b9f3cd753", s)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tests := []struct {
+		data   string
+		format uulid.Format
+	}{
+		{string(id[:]), uulid.FormatBinary},
+		{"0178a2849eafb3e7036d5b1b9f3cd753", uulid.FormatHex},
+		{"0178a284-9eaf-b3e7-036d-5b1b9f3cd753", uulid.FormatUUID},
+		{"01F2H897NFPFKG6VAV3EFKSNTK", uulid.FormatBase32},
+		{"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753}", uulid.FormatBraced},
+		{"URN:UUID:0178a284-9eaf-b3e7-036d-5b1b9f3cd753", uulid.FormatURN},
+	}
+
+	for _, test := range tests {
+		parsed, f, err := uulid.ParseFormat([]byte(test.data))
+		if err != nil || f != test.format || parsed.Compare(id) != 0 {
+			t.Errorf("parse error for %q, expected: %s and %s, got: %s and %s, error: %v",
+				test.data, id, test.format, parsed, f, err)
+		}
+	}
+
+	for _, data := range []string{"", "0178a284", "[0178a284-9eaf-b3e7-036d-5b1b9f3cd753]", "01F2H897NFPFKG6VAV3EFKSNT!"} {
+		if _, f, err := uulid.ParseFormat([]byte(data)); err == nil || f != 0 {
+			t.Errorf("expected error and zero format for %q, got: %s, error: %v", data, f, err)
+		}
+	}
+
+	if s := uulid.FormatBase32.String(); s != "base32" {
+		t.Errorf("format error, expected: base32, got: %s", s)
+	}
+
+	if s := uulid.Format(0).String(); s != "Format(0)" {
+		t.Errorf("format error, expected: Format(0), got: %s", s)
+	}
+}
+
+func TestUULID_FormatAs(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tests := []struct {
+		format   uulid.Format
+		expected string
+	}{
+		{uulid.FormatBinary, string(id[:])},
+		{uulid.FormatHex, "0178a2849eafb3e7036d5b1b9f3cd753"},
+		{uulid.FormatUUID, "0178a284-9eaf-b3e7-036d-5b1b9f3cd753"},
+		{uulid.FormatBase32, "01F2H897NFPFKG6VAV3EFKSNTK"},
+		{uulid.FormatBraced, "{0178a284-9eaf-b3e7-036d-5b1b9f3cd753}"},
+		{uulid.FormatURN, "urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753"},
+		{uulid.Format(0), ""},
+	}
+
+	for _, test := range tests {
+		s := id.FormatAs(test.format)
+		if s != test.expected {
+			t.Errorf("format error for %s, expected: %q, got: %q", test.format, test.expected, s)
+		}
+
+		if test.format == 0 {
+			continue
+		}
+
+		parsed, f, err := uulid.ParseFormat([]byte(s))
+		if err != nil || f != test.format || parsed.Compare(id) != 0 {
+			t.Errorf("round trip error for %s, expected: %s, got: %s and %s, error: %v",
+				test.format, id, parsed, f, err)
+		}
+	}
+}
@@ -1,31 +1,220 @@
 package uulid
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"io"
+	"math"
 	"math/bits"
+	"sort"
 	"sync"
 	"time"
 )
 
+const (
+	// subMillisecondShift is the position of the microseconds
+	// within the high entropy bits for WithSubMillisecondTime.
+	subMillisecondShift = 6
+	subMillisecondMask  = 1<<subMillisecondShift - 1
+
+	// versionMask and variantMask are the positions of the RFC 4122
+	// version and variant bits within the high and low entropy bits.
+	versionMask    = 0xF000
+	variantMask    = 0xC000000000000000
+	variantRFC4122 = 0x8000000000000000
+)
+
+// RNGKind selects the pseudo random number generator used by the Generator
+// to derive the entropy from its seed.
+type RNGKind int
+
+const (
+	// RNGWyhash is the default wyhash based mix.
+	RNGWyhash RNGKind = iota
+	// RNGSplitMix64 is the SplitMix64 generator by Steele, Lea and Flood.
+	RNGSplitMix64
+)
+
 // Generator implements an UUID generator based on the ULID spec.
 // The generated UULID is monotonically increased for calls within the same millisecond.
 type Generator struct {
-	mu   sync.Mutex
-	seed uint64
-	ms   uint64
-	hi   uint16
-	lo   uint64
+	mu       sync.Mutex
+	now      func() time.Time
+	epoch    time.Time
+	entropy  io.Reader
+	buf      [10]byte
+	rollover bool
+	randInc  bool
+	zeroLo   bool
+	subms    bool
+	node     bool
+	nodeID   uint16
+	version  byte
+	rng      RNGKind
+	path     string
+	observer func(e Event)
+	events   [eventCount]int
+	ahead    uint64
+	seed     uint64
+	ms       uint64
+	hi       uint16
+	lo       uint64
+}
+
+// GeneratorState is a snapshot of the Generator monotonic state,
+// as returned by Generator.State and restored by Generator.SetState.
+type GeneratorState struct {
+	Seed  uint64 // the seed of the internal RNG
+	Ms    uint64 // the millisecond of the last created UULID
+	Hi    uint16 // the high entropy bits
+	Lo    uint64 // the low entropy bits
+	Ahead uint64 // the milliseconds rolled over ahead of the clock
+}
+
+// Option configures a Generator.
+type Option func(r *Generator)
+
+// WithClock sets the function used by the Generator to get the current time.
+// The function is always called with the Generator lock held.
+func WithClock(now func() time.Time) Option {
+	return func(r *Generator) {
+		r.now = now
+	}
+}
+
+// WithOverflowRollover configures the Generator to advance to the next millisecond
+// instead of returning ErrMonotonicOverflow when the entropy overflows within the
+// same millisecond. The Generator may then run ahead of its clock, and calls with a
+// time within the rolled over interval are generated in the rolled over millisecond.
+// ErrBigTime is returned if rolling over would exceed MaxTimestamp.
+func WithOverflowRollover(rollover bool) Option {
+	return func(r *Generator) {
+		r.rollover = rollover
+	}
+}
+
+// WithRandomIncrement configures the Generator to increment the entropy by a random
+// amount between 1 and 2^32 within the same millisecond, instead of by exactly one,
+// so the next UULID is not trivially predictable from the previous one while the
+// UULIDs remain monotonically increased.
+//
+// The capacity before ErrMonotonicOverflow is reduced by the average increment of
+// 2^31, from 2^80 to about 2^49 UULIDs per millisecond, to about 2^33 with WithNodeID
+// and to about 2^43 with a UUID version.
+// It has no effect for Generators that read the entropy from a reader.
+func WithRandomIncrement() Option {
+	return func(r *Generator) {
+		r.randInc = true
+	}
+}
+
+// WithCounterResetToZero configures the Generator to reset the low 64 bits of the
+// entropy to zero on every new millisecond, randomizing only the high 16 bits.
+// This maximizes the capacity before ErrMonotonicOverflow to at least 2^64 UULIDs per
+// millisecond, or 2^62 with a UUID version, at the cost of predictable low entropy bits.
+func WithCounterResetToZero() Option {
+	return func(r *Generator) {
+		r.zeroLo = true
+	}
+}
+
+// WithRandomEntropy configures the Generator to read fresh entropy from crypto/rand
+// for every UULID instead of monotonically increasing it within the same millisecond.
+// This makes consecutive UULIDs unpredictable at the cost of performance, and UULIDs
+// generated within the same millisecond are NOT guaranteed to be ordered.
+func WithRandomEntropy() Option {
+	return func(r *Generator) {
+		r.entropy = rand.Reader
+	}
+}
+
+// WithSubMillisecondTime configures the Generator to encode the microseconds within
+// the millisecond in the 10 most significant bits of the entropy, trading randomness
+// for a finer time resolution that is preserved across generators.
+// The resulting time can be decoded with UULID.MicroTime().
+//
+// The entropy, and the capacity before ErrMonotonicOverflow, is reduced to 70 bits
+// within each microsecond. UULIDs remain monotonically increased within the same
+// millisecond, and overflowing increments advance the encoded microseconds.
+func WithSubMillisecondTime() Option {
+	return func(r *Generator) {
+		r.subms = true
+	}
+}
+
+// WithEpoch configures the Generator to store the timestamps as milliseconds since
+// the given epoch instead of the Unix epoch, which shifts the supported time range to
+// start at the epoch. ErrSmallTime is returned for times before the epoch.
+//
+// UULIDs created with different epochs are NOT comparable, and their time must be
+// read with UULID.EpochTime() using the same epoch.
+func WithEpoch(epoch time.Time) Option {
+	return func(r *Generator) {
+		r.epoch = epoch
+	}
+}
+
+// WithNodeID configures the Generator to set the 16 most significant bits of the
+// entropy to the given node identifier, which can be read with UULID.NodeID().
+//
+// The entropy, and the capacity before ErrMonotonicOverflow, is reduced to the
+// 64 bits of the monotonic counter within the same millisecond.
+// It cannot be combined with WithSubMillisecondTime, see ErrInvalidOption.
+func WithNodeID(id uint16) Option {
+	return func(r *Generator) {
+		r.node = true
+		r.nodeID = id
+	}
+}
+
+// WithUUIDVersion8 configures the Generator to set the RFC 4122 version field
+// to 8 (custom) and the variant field to RFC 4122, so the UULIDs are spec valid
+// UUIDv8 values for systems with a strict UUID validation.
+// The version can be read with UULID.UUIDVersion().
+//
+// The entropy, and the capacity before ErrMonotonicOverflow, is reduced by the
+// 6 bits of the version and variant fields. It cannot be combined with
+// WithSubMillisecondTime or WithNodeID, as the version field overlaps their
+// bits, see ErrInvalidOption.
+func WithUUIDVersion8() Option {
+	return func(r *Generator) {
+		r.version = 8
+	}
+}
+
+// WithUUIDVersion7 configures the Generator to create RFC 9562 UUIDv7 values,
+// setting the version field to 7 and the variant field to RFC 4122. UUIDv7 shares
+// the 48 bits millisecond timestamp prefix of ULIDs, so the UULIDs remain sortable
+// and interoperable with the standard UUIDv7 tooling. See UULID.IsUUIDv7().
+//
+// As with WithUUIDVersion8, the entropy is reduced by the 6 bits of the version
+// and variant fields and it cannot be combined with WithSubMillisecondTime or WithNodeID,
+// see ErrInvalidOption.
+func WithUUIDVersion7() Option {
+	return func(r *Generator) {
+		r.version = 7
+	}
+}
+
+// WithRNG sets the pseudo random number generator used to derive the entropy
+// from the Generator seed. It has no effect for NewGeneratorWithReader
+// or WithRandomEntropy, which read the entropy from a reader.
+func WithRNG(kind RNGKind) Option {
+	return func(r *Generator) {
+		r.rng = kind
+	}
 }
 
 // NewGenerator is like NewGeneratorWithSeed()
 // but uses a secure random seed from crypto/rand.
-func NewGenerator() (r *Generator, err error) {
+// ErrInvalidOption is returned for incompatible options.
+func NewGenerator(opts ...Option) (r *Generator, err error) {
 	b := make([]byte, 8)
 	if _, err := rand.Read(b); err != nil {
 		return nil, err
 	}
-	return NewGeneratorWithSeed(binary.BigEndian.Uint64(b)), nil
+	return newGenerator(binary.BigEndian.Uint64(b), opts...)
 }
 
 // NewGeneratorWithSeed creates a new UULID generator.
@@ -33,61 +222,510 @@ func NewGenerator() (r *Generator, err error) {
 //
 // Ensure that a good random seed is used or use NewGenerator()
 // which provides a secure seed from crypto/rand.
-func NewGeneratorWithSeed(seed uint64) (r *Generator) {
-	return &Generator{seed: seed}
+//
+// It panics with ErrInvalidOption for incompatible options,
+// which are returned as an error by NewGenerator.
+func NewGeneratorWithSeed(seed uint64, opts ...Option) (r *Generator) {
+	r, err := newGenerator(seed, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// newGenerator creates a Generator with the given seed and options,
+// returning ErrInvalidOption for options that overlap the same entropy bits.
+func newGenerator(seed uint64, opts ...Option) (r *Generator, err error) {
+	r = &Generator{seed: seed, now: time.Now}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.version != 0 && (r.subms || r.node) || r.subms && r.node {
+		return nil, ErrInvalidOption
+	}
+
+	return r, nil
+}
+
+// NewGeneratorWithReader creates a new UULID generator that reads
+// the 10 bytes of entropy for every UULID from the given reader.
+// As with WithRandomEntropy, UULIDs generated within the same
+// millisecond are NOT guaranteed to be ordered.
+//
+// Errors from the reader are returned when creating UULIDs.
+func NewGeneratorWithReader(rd io.Reader, opts ...Option) (r *Generator) {
+	r = NewGeneratorWithSeed(0, opts...)
+	r.entropy = rd
+	return r
+}
+
+// NewTestGenerator creates a deterministic UULID generator for tests.
+// The clock starts at the given start time and is advanced by step
+// on every call, and the entropy is derived only from the seed, so the
+// same seed, start and step always produce the same sequence of UULIDs.
+func NewTestGenerator(seed uint64, start time.Time, step time.Duration, opts ...Option) (r *Generator) {
+	now := start
+	clock := func() (t time.Time) {
+		t, now = now, now.Add(step)
+		return t
+	}
+
+	return NewGeneratorWithSeed(seed, append([]Option{WithClock(clock)}, opts...)...)
 }
 
-// New creates a UULID with the current system time.
+// New creates a UULID with the current time from the Generator clock.
 func (r *Generator) New() (id UULID, err error) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.newAt(r.clock())
+}
+
+// NewContext is like New but returns ctx.Err() if the context is done before
+// the entropy is obtained, which allows cancelling the wait on a blocking
+// entropy reader from NewGeneratorWithReader. An abandoned read still holds
+// the Generator lock until the reader returns.
+func (r *Generator) NewContext(ctx context.Context) (id UULID, err error) {
+	if err = ctx.Err(); err != nil {
+		return id, err
+	}
+
+	if r.entropy == nil {
+		return r.New()
+	}
+
+	// read the entropy in a separate goroutine that can be abandoned
+	// if the context is done while it blocks on the reader
+	done := make(chan error, 1)
+	e := make([]byte, 10)
+
+	go func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		_, err := io.ReadFull(r.entropy, e)
+		done <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return id, ctx.Err()
+	case err = <-done:
+		if err != nil {
+			return id, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.newWithEntropy(r.clock(), e)
+}
+
+// NewWithEntropy creates a UULID with the current time from the Generator clock
+// and the given 10 bytes of entropy, bypassing the Generator entropy.
+// ErrDataSize is returned if len(entropy) != 10.
+//
+// The Generator still rejects times before its previous call with ErrSmallTime,
+// but the ordering of UULIDs within the same millisecond is the caller's responsibility.
+func (r *Generator) NewWithEntropy(entropy []byte) (id UULID, err error) {
+	if len(entropy) != 10 {
+		return id, ErrDataSize
+	}
+
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.newWithEntropy(r.clock(), entropy)
+}
+
+// NewN fills dst with UULIDs created with the current time from the Generator clock.
+// The batch is created under a single lock acquisition and clock read,
+// and is monotonically increased within dst.
+func (r *Generator) NewN(dst []UULID) (err error) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.newN(r.clock(), dst)
+}
+
+// NewNAt fills dst with UULIDs created with the given time under a single lock
+// acquisition, producing a dense block within the millisecond of t that is
+// monotonically increased within dst. ErrMonotonicOverflow is returned if the
+// entropy overflows, unless the Generator uses WithOverflowRollover.
+func (r *Generator) NewNAt(t time.Time, dst []UULID) (err error) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.newN(t, dst)
+}
+
+// NewSpread creates n UULIDs with times linearly spaced between start and end,
+// inclusive, sorted in ascending order, such as for generating test data.
+// ErrInvalidRange is returned if start is after end and ErrBigTime if end is after
+// MaxTime(). As with NewAt, ErrSmallTime is returned if start is before the previous
+// call to the Generator.
+func (r *Generator) NewSpread(start, end time.Time, n int) (ids []UULID, err error) {
+	if start.After(end) {
+		return nil, ErrInvalidRange
+	}
+
+	r.mu.Lock()
+	defer r.unlock()
+
+	first, err := r.timestamp(start)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := r.timestamp(end)
+	if err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, nil
+	}
+
+	ids = make([]UULID, n)
+	for i := range ids {
+		// the offset of start in milliseconds, keeping its sub millisecond time
+		var off uint64
+		if n > 1 {
+			hi, lo := bits.Mul64(last-first, uint64(i))
+			off, _ = bits.Div64(hi, lo, uint64(n-1))
+		}
+
+		t := time.Unix(start.Unix()+int64(off/1000), int64(start.Nanosecond())+int64(off%1000)*int64(time.Millisecond))
+		if ids[i], err = r.newAt(t); err != nil {
+			return nil, err
+		}
+	}
+
+	// the entropy read from a reader is not ordered within the same millisecond
+	if r.entropy != nil {
+		sort.Slice(ids, func(i, j int) bool { return ids[i].Compare(ids[j]) < 0 })
+	}
+
+	return ids, nil
+}
+
+// NewAt creates a UULID with the given time.
+// Calls within the same millisecond of the previous call are monotonically increased.
+func (r *Generator) NewAt(t time.Time) (id UULID, err error) {
+	r.mu.Lock()
+	defer r.unlock()
+
+	return r.newAt(t)
+}
+
+// Reset re-initializes the Generator monotonic state as if freshly constructed
+// with the given seed, keeping the configured options. The state of a clock set
+// with WithClock or NewTestGenerator is not reset.
+//
+// It is safe to call concurrently, but UULIDs created afterwards are NOT
+// ordered with the ones created before the Reset.
+func (r *Generator) Reset(seed uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seed = seed
+	r.ms, r.hi, r.lo = 0, 0, 0
+	r.ahead = 0
+}
+
+// Last returns the Generator monotonic state from the last created UULID,
+// its millisecond and the high and low entropy bits that will be incremented
+// by the next call within the same millisecond. It doesn't modify the state.
+//
+// For Generators reading the entropy from a reader, only the millisecond
+// reflects the last created UULID.
+func (r *Generator) Last() (ms uint64, hi uint16, lo uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.ms, r.hi, r.lo
+}
+
+// RemainingThisMillisecond returns how many more UULIDs can be created within the
+// millisecond of the last created UULID before ErrMonotonicOverflow, from the current
+// position of the entropy increment, saturating at math.MaxUint64. A new millisecond
+// reseeds the entropy and thus the remaining capacity.
+//
+// It assumes increments by one, and is a lower bound with WithRandomIncrement. For
+// Generators reading the entropy from a reader, which never overflow, it returns
+// math.MaxUint64.
+func (r *Generator) RemainingThisMillisecond() (n uint64) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	ms := Timestamp(time.Now())
+	if r.entropy != nil {
+		return math.MaxUint64
+	}
+
+	// the remaining increments of the entropy bits not fixed by the Generator options
+	hiMask, loMask := r.masks()
+	hi, lo := uint64(^hiMask&^r.hi), ^loMask&^r.lo
+	shift := uint(bits.OnesCount64(^loMask))
+
+	if hi > 0 && (shift == 64 || hi > (math.MaxUint64-lo)>>shift) {
+		return math.MaxUint64
+	}
+
+	return hi<<shift + lo
+}
+
+// State returns a snapshot of the Generator monotonic state, including the seed of
+// the internal RNG, which can be restored with SetState to reproduce the sequence of
+// UULIDs, such as when debugging ordering anomalies. The configured options are not
+// part of the state, and for Generators reading the entropy from a reader the
+// sequence is only reproduced with the same entropy.
+func (r *Generator) State() (s GeneratorState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return GeneratorState{Seed: r.seed, Ms: r.ms, Hi: r.hi, Lo: r.lo, Ahead: r.ahead}
+}
+
+// SetState restores the Generator monotonic state from a snapshot returned by State.
+// A Generator configured with the same options and clock then creates the same
+// sequence of UULIDs as the Generator at the time of the snapshot.
+func (r *Generator) SetState(s GeneratorState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seed, r.ms, r.hi, r.lo, r.ahead = s.Seed, s.Ms, s.Hi, s.Lo, s.Ahead
+}
+
+func (r *Generator) newN(t time.Time, dst []UULID) (err error) {
+	for i := range dst {
+		if dst[i], err = r.newAt(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Generator) newAt(t time.Time) (id UULID, err error) {
+	ms, err := r.timestamp(t)
+	if err != nil {
+		return id, err
+	}
+
+	var us uint16
+	if r.subms {
+		us = uint16(t.Nanosecond() / int(time.Microsecond) % 1000)
+	}
+
+	if ms, err = r.read(id[6:], ms, us); err != nil {
+		return id, err
+	}
 
 	if err = id.SetTimestamp(ms); err != nil {
 		return id, err
 	}
 
-	if err = r.read(id[6:], ms); err != nil {
+	if r.path != "" {
+		if err = r.persist(); err != nil {
+			return UULID{}, err
+		}
+	}
+
+	return id, nil
+}
+
+// newWithEntropy is like newAt but uses the given entropy
+// instead of the Generator entropy.
+func (r *Generator) newWithEntropy(t time.Time, e []byte) (id UULID, err error) {
+	ms, err := r.timestamp(t)
+	if err != nil {
 		return id, err
 	}
 
+	if ms < r.ms && r.ms-ms > r.ahead {
+		r.record(EventClockRegression)
+		return id, ErrSmallTime
+	}
+
+	// the Generator entropy is left untouched, so the given
+	// entropy doesn't shift the sequence of the next calls
+	if ms > r.ms {
+		r.ms, r.ahead = ms, 0
+	}
+
+	_ = id.SetTimestamp(ms)
+	copy(id[6:], e)
+	r.setVersion(id[6:])
+
+	if r.path != "" {
+		if err = r.persist(); err != nil {
+			return UULID{}, err
+		}
+	}
+
 	return id, nil
 }
 
+// timestamp converts t to the Generator timestamp.
+func (r *Generator) timestamp(t time.Time) (ms uint64, err error) {
+	if r.epoch.IsZero() {
+		return timestamp(t)
+	}
+	return epochTimestamp(t, r.epoch)
+}
+
+// clock returns the current time, defaulting to time.Now
+// for a zero value Generator.
+func (r *Generator) clock() (t time.Time) {
+	if r.now == nil {
+		return time.Now()
+	}
+	return r.now()
+}
+
 // read generates a pseudo random entropy that is
-// incremented monotonically within the same millisecond interval
-func (r *Generator) read(p []byte, ms uint64) (err error) {
+// incremented monotonically within the same millisecond interval.
+// The us microseconds within the millisecond are only used for WithSubMillisecondTime.
+// It returns the millisecond which the entropy was generated for,
+// which differs from the given ms after an overflow rollover.
+func (r *Generator) read(p []byte, ms uint64, us uint16) (m uint64, err error) {
+	// time must not travel backwards from the previous call,
+	// unless within the interval the generator has rolled over ahead of it
+	if ms < r.ms {
+		if r.ms-ms > r.ahead {
+			r.record(EventClockRegression)
+			return ms, ErrSmallTime
+		}
+		r.ahead = r.ms - ms
+		ms, us = r.ms, 0
+	}
+
+	// non-monotonic entropy read from the entropy source
+	if r.entropy != nil {
+		// read through the generator buffer so p doesn't escape to the heap
+		if _, err = io.ReadFull(r.entropy, r.buf[:]); err != nil {
+			return ms, err
+		}
+
+		copy(p, r.buf[:])
+		r.setVersion(p)
+		r.ms = ms
+		return ms, nil
+	}
+
 	// within the same millisecond interval of the previous call
 	// increment lower entropy bytes and return
 	if r.ms == ms {
-		lo := r.lo
+		// a later microsecond within the same millisecond interval
+		// advances the sub millisecond time bits instead
+		if r.subms && us<<subMillisecondShift > r.hi&^subMillisecondMask {
+			r.advance(ms, us)
+			binary.BigEndian.PutUint16(p[:2], r.hi)
+			binary.BigEndian.PutUint64(p[2:], r.lo)
+			return ms, nil
+		}
+
+		// increment only the entropy bits that are not fixed by the
+		// Generator options, carrying over the fixed ones
+		hiMask, loMask := r.masks()
+		inc := uint64(1)
+		if r.randInc {
+			inc += r.uint64r() >> 32
+		}
+
+		// the fixed low bits are the most significant ones, so
+		// the carry of the increment propagates through them
+		lo, carry := bits.Add64(r.lo|loMask, inc, 0)
 		hi := r.hi
 
-		if r.lo++; r.lo < lo {
-			if r.hi++; r.hi < hi {
-				return ErrMonotonicOverflow
+		if carry != 0 {
+			if hi = (hi | hiMask) + 1; hi == 0 {
+				if !r.rollover {
+					r.record(EventOverflow)
+					return ms, ErrMonotonicOverflow
+				}
+
+				if ms >= MaxTimestamp {
+					return ms, ErrBigTime
+				}
+
+				r.record(EventRollover)
+				r.ahead++
+				r.advance(ms+1, 0)
+				binary.BigEndian.PutUint16(p[:2], r.hi)
+				binary.BigEndian.PutUint64(p[2:], r.lo)
+				return r.ms, nil
 			}
 		}
 
+		r.hi, r.lo = hi&^hiMask|r.hi&hiMask, lo&^loMask|r.lo&loMask
 		binary.BigEndian.PutUint16(p[:2], r.hi)
 		binary.BigEndian.PutUint64(p[2:], r.lo)
-		return nil
+		return ms, nil
 	}
 
-	r.advance(ms)
+	r.ahead = 0
+	r.advance(ms, us)
 	binary.BigEndian.PutUint16(p[:2], r.hi)
 	binary.BigEndian.PutUint64(p[2:], r.lo)
-	return nil
+	return ms, nil
 }
 
-func (r *Generator) advance(ms uint64) {
+func (r *Generator) advance(ms uint64, us uint16) {
 	r.ms = ms
 	r.hi = uint16(r.uint64r())
-	r.lo = r.uint64r()
+	r.lo = 0
+	if !r.zeroLo {
+		r.lo = r.uint64r()
+	}
+
+	if r.subms {
+		r.hi = us<<subMillisecondShift | r.hi&subMillisecondMask
+	}
+
+	if r.node {
+		r.hi = r.nodeID
+	}
+
+	if r.version != 0 {
+		r.hi = r.hi&^versionMask | uint16(r.version)<<12
+		r.lo = r.lo&^variantMask | variantRFC4122
+	}
+}
+
+// masks returns the entropy bits that are fixed by the Generator options.
+func (r *Generator) masks() (hi uint16, lo uint64) {
+	if r.node {
+		hi = 0xFFFF
+	}
+
+	if r.version != 0 {
+		hi |= versionMask
+		lo |= variantMask
+	}
+
+	return hi, lo
+}
+
+// setVersion sets the version and variant fields in the given
+// 10 bytes of entropy if configured with a UUID version.
+func (r *Generator) setVersion(p []byte) {
+	if r.version != 0 {
+		p[0] = p[0]&0x0F | r.version<<4
+		p[2] = p[2]&0x3F | 0x80
+	}
 }
 
 func (r *Generator) uint64r() (v uint64) {
+	if r.rng == RNGSplitMix64 {
+		r.seed += 0x9e3779b97f4a7c15
+		v = r.seed
+		v = (v ^ v>>30) * 0xbf58476d1ce4e5b9
+		v = (v ^ v>>27) * 0x94d049bb133111eb
+		return v ^ v>>31
+	}
+
 	r.seed += 0xa0761d6478bd642f
 	hi, lo := bits.Mul64(r.seed^0xe7037ed1a0b428db, r.seed)
 	return hi ^ lo
@@ -1,11 +1,20 @@
 package uulid_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/brunotm/uulid"
 )
 
+// golden is the first UULID from NewTestGenerator(1, uulid.Time(timestamp), 0).
+const golden = "0178a284-9eaf-ed2c-61d6-d24b1c9aad40"
+
 func TestNew_Generator(t *testing.T) {
 	var err error
 	if _, err = uulid.NewGenerator(); err != nil {
@@ -24,11 +33,1002 @@ func TestGenerator_New(t *testing.T) {
 		t.Error(err)
 	}
 
-	if id.Compare(uulid.UULID{}) == 0 {
+	if id.IsZero() {
 		t.Error("non-initialized uulid")
 	}
 }
 
+func TestGenerator_NewAt(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1)
+	tm := uulid.Time(timestamp)
+
+	id1, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	id2, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Timestamp() != timestamp || id2.Timestamp() != timestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d and %d", timestamp, id1.Timestamp(), id2.Timestamp())
+	}
+
+	if id1.Compare(id2) != -1 {
+		t.Errorf("compare error, expected: %d, got: %d", -1, id1.Compare(id2))
+	}
+
+	if _, err = r.NewAt(uulid.MaxTime().Add(time.Millisecond)); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %s instead", err)
+	}
+}
+
+func TestGenerator_WithClock(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithClock(func() time.Time { return now }))
+
+	id1, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	id2, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Timestamp() != timestamp || id2.Timestamp() != timestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d and %d", timestamp, id1.Timestamp(), id2.Timestamp())
+	}
+
+	if id1.Compare(id2) != -1 {
+		t.Errorf("compare error, expected: %d, got: %d", -1, id1.Compare(id2))
+	}
+
+	now = now.Add(time.Millisecond)
+	id3, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id3.Timestamp() != timestamp+1 {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp+1, id3.Timestamp())
+	}
+}
+
+func TestGenerator_ErrSmallTime(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithClock(func() time.Time { return now }))
+
+	if _, err := r.New(); err != nil {
+		t.Error(err)
+	}
+
+	now = now.Add(-time.Millisecond)
+	if _, err := r.New(); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %s instead", err)
+	}
+
+	if _, err := r.NewAt(now); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %s instead", err)
+	}
+
+	now = now.Add(time.Millisecond)
+	if _, err := r.New(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerator_ErrMonotonicOverflow(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithClock(func() time.Time { return now }))
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFE)
+
+	if _, err := r.New(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := r.New(); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %s instead", err)
+	}
+
+	// the state must not be modified by the overflow
+	if _, err := r.New(); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %s instead", err)
+	}
+}
+
+func TestGenerator_WithOverflowRollover(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1,
+		uulid.WithClock(func() time.Time { return now }),
+		uulid.WithOverflowRollover(true))
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+
+	id1, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Timestamp() != timestamp+1 {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp+1, id1.Timestamp())
+	}
+
+	// clock still behind the rolled over millisecond
+	id2, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Compare(id2) != -1 {
+		t.Errorf("compare error, expected: %d, got: %d", -1, id1.Compare(id2))
+	}
+
+	// clock regression beyond the rolled over interval
+	now = now.Add(-time.Millisecond)
+	if _, err = r.New(); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %s instead", err)
+	}
+
+	uulid.SetGeneratorState(r, uulid.MaxTimestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+	if _, err = r.NewAt(uulid.MaxTime()); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %s instead", err)
+	}
+}
+
+func TestGenerator_WithRandomIncrement(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithRandomIncrement())
+
+	var prev uulid.UULID
+	ones := 0
+	for i := 0; i < 1024; i++ {
+		id, err := r.NewAt(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i > 0 {
+			if prev.Compare(id) != -1 {
+				t.Fatalf("compare error, expected: %s < %s", prev, id)
+			}
+
+			if id.Counter()-prev.Counter() == 1 {
+				ones++
+			}
+		}
+		prev = id
+	}
+
+	if ones == 1023 {
+		t.Error("increment error, expected random increments, got: all increments by one")
+	}
+
+	// overflow is handled as for the increment by one
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+	if _, err := r.NewAt(now); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %v instead", err)
+	}
+
+	r = uulid.NewGeneratorWithSeed(1, uulid.WithRandomIncrement(), uulid.WithOverflowRollover(true))
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+	if id, err := r.NewAt(now); err != nil || id.Timestamp() != timestamp+1 {
+		t.Errorf("timestamp error, expected: %d, got: %d, error: %v", timestamp+1, id.Timestamp(), err)
+	}
+
+	// the increment carries over into the high entropy bits
+	r = uulid.NewGeneratorWithSeed(1, uulid.WithRandomIncrement())
+	uulid.SetGeneratorState(r, timestamp, 0x0001, 0xFFFFFFFFFFFFFFFF)
+	if id, err := r.NewAt(now); err != nil || id[6] != 0x00 || id[7] != 0x02 {
+		t.Errorf("carry error, expected high entropy: 0002, got: %x, error: %v", id[6:8], err)
+	}
+}
+
+func TestGenerator_WithCounterResetToZero(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithCounterResetToZero())
+
+	id, err := r.NewAt(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id.Counter() != 0 {
+		t.Errorf("counter error, expected: %d, got: %d", 0, id.Counter())
+	}
+
+	// a dense burst within the same millisecond
+	ids := make([]uulid.UULID, 1<<16)
+	if err = r.NewNAt(now, ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := ids[len(ids)-1].Counter(); c != uint64(len(ids)) {
+		t.Errorf("counter error, expected: %d, got: %d", len(ids), c)
+	}
+
+	// the counter resets on the next millisecond
+	if id, err = r.NewAt(now.Add(time.Millisecond)); err != nil || id.Counter() != 0 {
+		t.Errorf("counter error, expected: %d, got: %d, error: %v", 0, id.Counter(), err)
+	}
+}
+
+func TestGenerator_NewWithEntropy(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithClock(func() time.Time { return now }))
+	e := []byte{0xb3, 0xe7, 0x03, 0x6d, 0x5b, 0x1b, 0x9f, 0x3c, 0xd7, 0x53}
+
+	id, err := r.NewWithEntropy(e)
+	if err != nil || id.String() != string(encoded) {
+		t.Errorf("entropy error, expected: %s, got: %s, error: %v", encoded, id.String(), err)
+	}
+
+	// the same entropy within the same millisecond is not incremented
+	if id2, err := r.NewWithEntropy(e); err != nil || id2.Compare(id) != 0 {
+		t.Errorf("entropy error, expected: %s, got: %s, error: %v", id.String(), id2.String(), err)
+	}
+
+	for _, e := range [][]byte{nil, e[:9], append(e, 0)} {
+		if _, err = r.NewWithEntropy(e); err != uulid.ErrDataSize {
+			t.Errorf("expected ErrDataSize, got %v instead", err)
+		}
+	}
+
+	now = now.Add(-time.Millisecond)
+	if _, err = r.NewWithEntropy(e); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %v instead", err)
+	}
+
+	if id, err = uulid.NewWithEntropy(e); err != nil || !bytes.Equal(id.Entropy(), e) {
+		t.Errorf("entropy error, expected: %x, got: %x, error: %v", e, id.Entropy(), err)
+	}
+}
+
+func TestGenerator_NewWithEntropy_State(t *testing.T) {
+	now := uulid.Time(timestamp)
+	clock := uulid.WithClock(func() time.Time { return now })
+	r1 := uulid.NewGeneratorWithSeed(1, clock)
+	r2 := uulid.NewGeneratorWithSeed(1, clock)
+
+	for _, r := range []*uulid.Generator{r1, r2} {
+		if _, err := r.New(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a new millisecond with the given entropy doesn't use the Generator entropy
+	now = now.Add(time.Millisecond)
+	s := r1.State()
+	if _, err := r1.NewWithEntropy(make([]byte, 10)); err != nil {
+		t.Fatal(err)
+	}
+
+	if cur := r1.State(); cur.Seed != s.Seed || cur.Hi != s.Hi || cur.Lo != s.Lo {
+		t.Errorf("state error, expected: %+v, got: %+v", s, cur)
+	}
+
+	now = now.Add(time.Millisecond)
+	id1, err := r1.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id2, err := r2.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id1.Compare(id2) != 0 {
+		t.Errorf("sequence error, expected: %s, got: %s", id2.String(), id1.String())
+	}
+}
+
+func TestGenerator_NewN(t *testing.T) {
+	r, err := uulid.NewGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ids := make([]uulid.UULID, 1024)
+	if err = r.NewN(ids); err != nil {
+		t.Error(err)
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].Compare(ids[i]) != -1 {
+			t.Errorf("compare error at %d, expected: %d, got: %d", i, -1, ids[i-1].Compare(ids[i]))
+		}
+	}
+}
+
+func TestGenerator_NewNAt(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1)
+	tm := uulid.Time(timestamp)
+
+	ids := make([]uulid.UULID, 1024)
+	if err := r.NewNAt(tm, ids); err != nil {
+		t.Error(err)
+	}
+
+	for i := range ids {
+		if ids[i].Timestamp() != timestamp {
+			t.Errorf("timestamp error at %d, expected: %d, got: %d", i, timestamp, ids[i].Timestamp())
+		}
+
+		if i > 0 && ids[i-1].Compare(ids[i]) != -1 {
+			t.Errorf("compare error at %d, expected: %d, got: %d", i, -1, ids[i-1].Compare(ids[i]))
+		}
+	}
+
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFE)
+	if err := r.NewNAt(tm, ids[:2]); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %v instead", err)
+	}
+
+	if err := r.NewNAt(uulid.MaxTime().Add(time.Millisecond), ids); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %v instead", err)
+	}
+}
+
+func TestGenerator_WithRandomEntropy(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1,
+		uulid.WithClock(func() time.Time { return now }),
+		uulid.WithRandomEntropy())
+
+	id1, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	id2, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Timestamp() != timestamp || id2.Timestamp() != timestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d and %d", timestamp, id1.Timestamp(), id2.Timestamp())
+	}
+
+	if id1.Compare(id2) == 0 {
+		t.Errorf("expected different entropy, got: %s and %s", id1.String(), id2.String())
+	}
+
+	now = now.Add(-time.Millisecond)
+	if _, err = r.New(); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %s instead", err)
+	}
+}
+
+func TestNewGeneratorWithReader(t *testing.T) {
+	entropy := []byte("0123456789abcdefghij")
+	r := uulid.NewGeneratorWithReader(bytes.NewReader(entropy))
+	tm := uulid.Time(timestamp)
+
+	id1, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	id2, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !bytes.Equal(id1.Entropy(), entropy[:10]) || !bytes.Equal(id2.Entropy(), entropy[10:]) {
+		t.Errorf("entropy error, expected: %s, got: %s and %s", entropy, id1.Entropy(), id2.Entropy())
+	}
+
+	if _, err = r.NewAt(tm); err != io.EOF {
+		t.Errorf("expected io.EOF, got %s instead", err)
+	}
+}
+
+func TestGenerator_WithSubMillisecondTime(t *testing.T) {
+	now := uulid.Time(timestamp).Add(250 * time.Microsecond)
+	r := uulid.NewGeneratorWithSeed(1,
+		uulid.WithClock(func() time.Time { return now }),
+		uulid.WithSubMillisecondTime())
+
+	id1, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !id1.MicroTime().Equal(now) {
+		t.Errorf("time error, expected: %s, got: %s", now, id1.MicroTime())
+	}
+
+	id2, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	now = now.Add(500 * time.Microsecond)
+	id3, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Timestamp() != timestamp || id3.Timestamp() != timestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d and %d", timestamp, id1.Timestamp(), id3.Timestamp())
+	}
+
+	if !id3.MicroTime().Equal(now) {
+		t.Errorf("time error, expected: %s, got: %s", now, id3.MicroTime())
+	}
+
+	if id1.Compare(id2) != -1 || id2.Compare(id3) != -1 {
+		t.Errorf("expected ordered: %s, %s and %s", id1.String(), id2.String(), id3.String())
+	}
+
+	// an earlier microsecond within the same millisecond keeps increasing
+	now = now.Add(-100 * time.Microsecond)
+	id4, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id3.Compare(id4) != -1 {
+		t.Errorf("compare error, expected: %d, got: %d", -1, id3.Compare(id4))
+	}
+}
+
+func TestGenerator_WithEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithEpoch(epoch))
+	tm := uulid.Time(timestamp)
+
+	id, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.Timestamp() != timestamp-uulid.Timestamp(epoch) {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp-uulid.Timestamp(epoch), id.Timestamp())
+	}
+
+	if !id.EpochTime(epoch).Equal(tm) {
+		t.Errorf("time error, expected: %s, got: %s", tm, id.EpochTime(epoch))
+	}
+
+	if _, err = r.NewAt(epoch.Add(-time.Millisecond)); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %s instead", err)
+	}
+
+	// times after the unix MaxTime() within the epoch range
+	if _, err = r.NewAt(uulid.MaxTime().Add(time.Hour)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerator_WithNodeID(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1,
+		uulid.WithClock(func() time.Time { return now }),
+		uulid.WithNodeID(0xCAFE))
+
+	prev, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	for i := 0; i < 1024; i++ {
+		if i%256 == 0 {
+			now = now.Add(time.Millisecond)
+		}
+
+		cur, err := r.New()
+		if err != nil {
+			t.Error(err)
+		}
+
+		if cur.NodeID() != 0xCAFE {
+			t.Errorf("node id error, expected: %x, got: %x", 0xCAFE, cur.NodeID())
+		}
+
+		if prev.Compare(cur) != -1 {
+			t.Errorf("compare error, expected: %d, got: %d", -1, prev.Compare(cur))
+		}
+		prev = cur
+	}
+
+	// the counter can't overflow into the node id
+	uulid.SetGeneratorState(r, uulid.Timestamp(now), 0xCAFE, 0xFFFFFFFFFFFFFFFF)
+	if _, err = r.New(); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %s instead", err)
+	}
+}
+
+func TestGenerator_WithUUIDVersion8(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithUUIDVersion8())
+	now := uulid.Time(timestamp)
+
+	var prev uulid.UULID
+	for i := 0; i < 1024; i++ {
+		id, err := r.NewAt(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id.UUIDVersion() != 8 || id[8]&0xC0 != 0x80 {
+			t.Fatalf("version error, expected: 8 with RFC 4122 variant, got: %s", id)
+		}
+
+		if i > 0 && prev.Compare(id) != -1 {
+			t.Fatalf("compare error, expected: %s < %s", prev, id)
+		}
+		prev = id
+	}
+
+	// the low counter carries over the variant bits into the high counter
+	uulid.SetGeneratorState(r, timestamp, 0x8000, 0xBFFFFFFFFFFFFFFF)
+	id, err := r.NewAt(now)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if expected := "0178a284-9eaf-8001-8000-000000000000"; id.String() != expected {
+		t.Errorf("increment error, expected: %s, got: %s", expected, id)
+	}
+
+	// and the high counter can't overflow into the version bits
+	uulid.SetGeneratorState(r, timestamp, 0x8FFF, 0xBFFFFFFFFFFFFFFF)
+	if _, err = r.NewAt(now); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %s instead", err)
+	}
+
+	// with an entropy reader
+	r = uulid.NewGeneratorWithReader(bytes.NewReader(bytes.Repeat([]byte{0xFF}, 10)), uulid.WithUUIDVersion8())
+	if id, err = r.New(); err != nil || id.UUIDVersion() != 8 || id[8]&0xC0 != 0x80 {
+		t.Errorf("version error, expected: 8 with RFC 4122 variant, got: %s, error: %v", id, err)
+	}
+}
+
+func TestGenerator_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []uulid.Option
+	}{
+		{"subms and node", []uulid.Option{uulid.WithSubMillisecondTime(), uulid.WithNodeID(0xABCD)}},
+		{"subms and version 8", []uulid.Option{uulid.WithSubMillisecondTime(), uulid.WithUUIDVersion8()}},
+		{"node and version 8", []uulid.Option{uulid.WithNodeID(0xABCD), uulid.WithUUIDVersion8()}},
+		{"version 8 and node", []uulid.Option{uulid.WithUUIDVersion8(), uulid.WithNodeID(0xABCD)}},
+		{"subms and version 7", []uulid.Option{uulid.WithSubMillisecondTime(), uulid.WithUUIDVersion7()}},
+		{"node and version 7", []uulid.Option{uulid.WithNodeID(0xABCD), uulid.WithUUIDVersion7()}},
+		{"version 7 and subms", []uulid.Option{uulid.WithUUIDVersion7(), uulid.WithSubMillisecondTime()}},
+	}
+
+	for _, test := range tests {
+		if _, err := uulid.NewGenerator(test.opts...); err != uulid.ErrInvalidOption {
+			t.Errorf("expected ErrInvalidOption for %s, got %v instead", test.name, err)
+		}
+
+		func() {
+			defer func() {
+				if err := recover(); err != uulid.ErrInvalidOption {
+					t.Errorf("expected ErrInvalidOption panic for %s, got %v instead", test.name, err)
+				}
+			}()
+			uulid.NewGeneratorWithSeed(1, test.opts...)
+		}()
+	}
+
+	if _, err := uulid.NewGenerator(uulid.WithUUIDVersion8(), uulid.WithOverflowRollover(true)); err != nil {
+		t.Errorf("expected compatible options, got %v", err)
+	}
+}
+
+func TestGenerator_WithUUIDVersion7(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithUUIDVersion7())
+	now := uulid.Time(timestamp)
+
+	var prev uulid.UULID
+	for i := 0; i < 1024; i++ {
+		id, err := r.NewAt(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !id.IsUUIDv7() {
+			t.Fatalf("version error, expected UUIDv7, got: %s", id)
+		}
+
+		if i > 0 && prev.Compare(id) != -1 {
+			t.Fatalf("compare error, expected: %s < %s", prev, id)
+		}
+		prev = id
+	}
+
+	if id := uulid.MustParseString(golden); id.IsUUIDv7() {
+		t.Errorf("version error, expected non UUIDv7, got: %s", id)
+	}
+}
+
+func TestGenerator_WithRNG(t *testing.T) {
+	for _, kind := range []uulid.RNGKind{uulid.RNGWyhash, uulid.RNGSplitMix64} {
+		// a new millisecond on every call reseeds the entropy from the RNG
+		r := uulid.NewTestGenerator(1, uulid.Time(timestamp), time.Millisecond, uulid.WithRNG(kind))
+
+		// chi-square over the byte values of the entropy
+		var counts [256]float64
+		n := 0
+		for i := 0; i < 1<<16; i++ {
+			id, err := r.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, b := range id[6:] {
+				counts[b]++
+				n++
+			}
+		}
+
+		expected := float64(n) / 256
+		var chi2 float64
+		for _, c := range counts {
+			chi2 += (c - expected) * (c - expected) / expected
+		}
+
+		// 255 degrees of freedom, the 99.99th percentile is about 347
+		if chi2 > 347 {
+			t.Errorf("distribution error for rng %d, expected chi-square: <= 347, got: %.2f", kind, chi2)
+		}
+	}
+
+	a := uulid.NewGeneratorWithSeed(1, uulid.WithRNG(uulid.RNGWyhash))
+	b := uulid.NewGeneratorWithSeed(1, uulid.WithRNG(uulid.RNGSplitMix64))
+	ida, _ := a.NewAt(uulid.Time(timestamp))
+	idb, _ := b.NewAt(uulid.Time(timestamp))
+	if ida.Compare(idb) == 0 {
+		t.Errorf("rng error, expected different entropy for the same seed, got: %s", ida)
+	}
+}
+
+func TestGenerator_NewContext(t *testing.T) {
+	r, err := uulid.NewGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err = r.NewContext(context.Background()); err != nil {
+		t.Error(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = r.NewContext(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %s instead", err)
+	}
+
+	// blocking entropy reader
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	r = uulid.NewGeneratorWithReader(pr)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err = r.NewContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %s instead", err)
+	}
+
+	r = uulid.NewGeneratorWithReader(bytes.NewReader([]byte("0123456789")))
+	id, err := r.NewContext(context.Background())
+	if err != nil || string(id.Entropy()) != "0123456789" {
+		t.Errorf("entropy error, expected: %s, got: %s, error: %s", "0123456789", id.Entropy(), err)
+	}
+}
+
+func TestNewTestGenerator(t *testing.T) {
+	start := uulid.Time(timestamp)
+	r1 := uulid.NewTestGenerator(1, start, time.Millisecond)
+	r2 := uulid.NewTestGenerator(1, start, time.Millisecond)
+
+	for i := 0; i < 16; i++ {
+		id1, err := r1.New()
+		if err != nil {
+			t.Error(err)
+		}
+
+		id2, err := r2.New()
+		if err != nil {
+			t.Error(err)
+		}
+
+		if id1.Compare(id2) != 0 {
+			t.Errorf("expected the same sequence, got: %s and %s", id1.String(), id2.String())
+		}
+
+		if id1.Timestamp() != timestamp+uint64(i) {
+			t.Errorf("timestamp error, expected: %d, got: %d", timestamp+uint64(i), id1.Timestamp())
+		}
+	}
+
+	// golden value for the seed and start
+	id, err := uulid.NewTestGenerator(1, start, 0).New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.String() != golden {
+		t.Errorf("golden error, expected: %s, got: %s", golden, id.String())
+	}
+}
+
+func TestGenerator_Counter(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1)
+	tm := uulid.Time(timestamp)
+
+	first, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	for i := uint64(1); i < 1024; i++ {
+		id, err := r.NewAt(tm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id.NodeID() != first.NodeID() || id.Counter() != first.Counter()+i {
+			t.Fatalf("counter error, expected: %d, got: %d", first.Counter()+i, id.Counter())
+		}
+	}
+
+	// the counter overflows into the high entropy bits
+	uulid.SetGeneratorState(r, timestamp, 1, 0xFFFFFFFFFFFFFFFF)
+	id, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.NodeID() != 2 || id.Counter() != 0 {
+		t.Errorf("counter error, expected: 2 and 0, got: %d and %d", id.NodeID(), id.Counter())
+	}
+}
+
+func TestGenerator_Reset(t *testing.T) {
+	tm := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1)
+
+	id1, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// a later time that would make the Reset time to be in the past
+	if _, err = r.NewAt(tm.Add(time.Second)); err != nil {
+		t.Error(err)
+	}
+
+	r.Reset(1)
+
+	id2, err := r.NewAt(tm)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id1.Compare(id2) != 0 {
+		t.Errorf("expected the same UULID after reset, got: %s and %s", id1.String(), id2.String())
+	}
+
+	if id2.String() != golden {
+		t.Errorf("golden error, expected: %s, got: %s", golden, id2.String())
+	}
+}
+
+func TestGenerator_Last(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1)
+
+	if ms, hi, lo := r.Last(); ms != 0 || hi != 0 || lo != 0 {
+		t.Errorf("state error, expected zero state, got: %d, %d, %d", ms, hi, lo)
+	}
+
+	id, err := r.NewAt(uulid.Time(timestamp))
+	if err != nil {
+		t.Error(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		ms, hi, lo := r.Last()
+		if ms != timestamp {
+			t.Errorf("timestamp error, expected: %d, got: %d", timestamp, ms)
+		}
+
+		if e := id.Entropy(); binary.BigEndian.Uint16(e[:2]) != hi || binary.BigEndian.Uint64(e[2:]) != lo {
+			t.Errorf("entropy error, expected: %x, got: %04x%016x", e, hi, lo)
+		}
+	}
+}
+
+func TestGenerator_NewSpread(t *testing.T) {
+	start := uulid.Time(timestamp)
+	end := start.Add(time.Hour)
+
+	r := uulid.NewGeneratorWithSeed(1)
+	ids, err := r.NewSpread(start, end, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, id := range ids {
+		expected := timestamp + uint64(i)*10*60*1000
+		if id.Timestamp() != expected {
+			t.Errorf("timestamp error at %d, expected: %d, got: %d", i, expected, id.Timestamp())
+		}
+	}
+
+	// more UULIDs than milliseconds within the range
+	r = uulid.NewGeneratorWithReader(bytes.NewReader(bytes.Repeat([]byte{0xFF, 0x00, 0x7F}, 1024)))
+	if ids, err = r.NewSpread(start, start.Add(time.Millisecond), 256); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids[0].Timestamp() != timestamp || ids[len(ids)-1].Timestamp() != timestamp+1 {
+		t.Errorf("timestamp error, expected: %d to %d, got: %d to %d",
+			timestamp, timestamp+1, ids[0].Timestamp(), ids[len(ids)-1].Timestamp())
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].Compare(ids[i]) > 0 {
+			t.Errorf("compare error at %d, expected: %s <= %s", i, ids[i-1], ids[i])
+		}
+	}
+
+	if ids, err = uulid.NewGeneratorWithSeed(1).NewSpread(start, end, 1); err != nil || len(ids) != 1 || ids[0].Timestamp() != timestamp {
+		t.Errorf("spread error, expected a single uulid at: %d, got: %v, error: %v", timestamp, ids, err)
+	}
+
+	r = uulid.NewGeneratorWithSeed(1)
+	if _, err = r.NewSpread(end, start, 2); err != uulid.ErrInvalidRange {
+		t.Errorf("expected ErrInvalidRange, got %v instead", err)
+	}
+
+	if _, err = r.NewSpread(start, uulid.MaxTime().Add(time.Millisecond), 2); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %v instead", err)
+	}
+}
+
+func TestGenerator_RemainingThisMillisecond(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1)
+
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFD)
+	for expected := uint64(2); ; expected-- {
+		if n := r.RemainingThisMillisecond(); n != expected {
+			t.Errorf("remaining error, expected: %d, got: %d", expected, n)
+		}
+
+		if expected == 0 {
+			break
+		}
+
+		if _, err := r.NewAt(now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := r.NewAt(now); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %v instead", err)
+	}
+
+	tests := []struct {
+		opt      uulid.Option
+		hi       uint16
+		lo       uint64
+		expected uint64
+	}{
+		{nil, 0xFFFF, 0, math.MaxUint64},
+		{nil, 0xFFFE, 0, math.MaxUint64},
+		{uulid.WithNodeID(0x0102), 0x0102, 0xFFFFFFFFFFFFFF00, 0xFF},
+		{uulid.WithUUIDVersion7(), 0x7FFF, 0xBFFFFFFFFFFFFF00, 0xFF},
+		{uulid.WithUUIDVersion7(), 0x7FFE, 0xBFFFFFFFFFFFFFFF, 1 << 62},
+	}
+
+	for _, test := range tests {
+		var opts []uulid.Option
+		if test.opt != nil {
+			opts = append(opts, test.opt)
+		}
+
+		r = uulid.NewGeneratorWithSeed(1, opts...)
+		uulid.SetGeneratorState(r, timestamp, test.hi, test.lo)
+		if n := r.RemainingThisMillisecond(); n != test.expected {
+			t.Errorf("remaining error for %04x%016x, expected: %d, got: %d", test.hi, test.lo, test.expected, n)
+		}
+	}
+
+	r = uulid.NewGeneratorWithReader(bytes.NewReader(make([]byte, 10)))
+	if n := r.RemainingThisMillisecond(); n != math.MaxUint64 {
+		t.Errorf("remaining error, expected: %d, got: %d", uint64(math.MaxUint64), n)
+	}
+}
+
+func TestGenerator_State(t *testing.T) {
+	tm := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1, uulid.WithOverflowRollover(true))
+
+	// a rolled over state ahead of the clock
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+	if _, err := r.NewAt(tm); err != nil {
+		t.Fatal(err)
+	}
+
+	s := r.State()
+	if s.Ms != timestamp+1 || s.Ahead != 1 {
+		t.Errorf("state error, expected: %d and %d ahead, got: %d and %d ahead", timestamp+1, 1, s.Ms, s.Ahead)
+	}
+
+	times := []time.Time{tm, tm.Add(time.Millisecond), tm.Add(2 * time.Millisecond), tm.Add(3 * time.Millisecond)}
+	expected := make([]uulid.UULID, len(times))
+	for i := range times {
+		var err error
+		if expected[i], err = r.NewAt(times[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// replay from the snapshot in a new Generator with the same options
+	replay := uulid.NewGeneratorWithSeed(2, uulid.WithOverflowRollover(true))
+	replay.SetState(s)
+	if replay.State() != s {
+		t.Errorf("state error, expected: %+v, got: %+v", s, replay.State())
+	}
+
+	for i := range times {
+		id, err := replay.NewAt(times[i])
+		if err != nil || id.Compare(expected[i]) != 0 {
+			t.Errorf("replay error at %d, expected: %s, got: %s, error: %v", i, expected[i], id, err)
+		}
+	}
+}
+
+func BenchmarkGenerator_NewN(b *testing.B) {
+	r, err := uulid.NewGenerator()
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.BinarySize * 1024)
+
+	b.RunParallel(func(pb *testing.PB) {
+		ids := make([]uulid.UULID, 1024)
+		for pb.Next() {
+			if err := r.NewN(ids); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGenerator_NewLoop(b *testing.B) {
+	r, err := uulid.NewGenerator()
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.BinarySize * 1024)
+
+	b.RunParallel(func(pb *testing.PB) {
+		var err error
+		ids := make([]uulid.UULID, 1024)
+		for pb.Next() {
+			for i := range ids {
+				if ids[i], err = r.New(); err != nil {
+					b.Error(err)
+				}
+			}
+		}
+	})
+}
+
 func BenchmarkTestGenerator_SeqSafety(b *testing.B) {
 	r, err := uulid.NewGenerator()
 	if err != nil {
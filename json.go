@@ -0,0 +1,87 @@
+package uulid
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+)
+
+// rfc3339ms is the RFC 3339 time format with a millisecond precision.
+const rfc3339ms = "2006-01-02T15:04:05.000Z07:00"
+
+// VerboseUULID is an UULID that is JSON encoded as an object with its decoded
+// UTC time and entropy alongside the id, mostly useful for debugging:
+//
+//	{"id":"0178a284-9eaf-b3e7-036d-5b1b9f3cd753","time":"2021-04-05T14:51:43.663Z","entropy":"b3e7036d5b1b9f3cd753"}
+//
+// It can be decoded from either the object or the plain string JSON encoding of an UULID.
+type VerboseUULID UULID
+
+// verboseUULID is the JSON object encoding of a VerboseUULID.
+type verboseUULID struct {
+	ID      UULID  `json:"id"`
+	Time    string `json:"time"`
+	Entropy string `json:"entropy"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (id VerboseUULID) MarshalJSON() (data []byte, err error) {
+	return json.Marshal(verboseUULID{
+		ID:      UULID(id),
+		Time:    UULID(id).Time().UTC().Format(rfc3339ms),
+		Entropy: hex.EncodeToString(UULID(id).Entropy()),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The UULID is decoded from the id field of the object encoding,
+// the time and entropy fields are ignored.
+func (id *VerboseUULID) UnmarshalJSON(data []byte) (err error) {
+	if len(data) > 0 && data[0] == '{' {
+		var v struct {
+			ID UULID `json:"id"`
+		}
+
+		if err = json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+
+		*id = VerboseUULID(v.ID)
+		return nil
+	}
+
+	return json.Unmarshal(data, (*UULID)(id))
+}
+
+// TimestampUULID is an UULID that can be JSON decoded from a number holding a Unix
+// time in milliseconds, as sent by APIs exposing creation times, besides the string
+// form. Numbers are decoded with FromTimestamp as the smallest UULID within the
+// millisecond, which is useful as a lower bound for range comparisons.
+//
+// It is always JSON encoded in the string form, so numbers don't round trip.
+type TimestampUULID UULID
+
+// MarshalJSON implements the json.Marshaler interface.
+func (id TimestampUULID) MarshalJSON() (data []byte, err error) {
+	return json.Marshal(UULID(id))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (id *TimestampUULID) UnmarshalJSON(data []byte) (err error) {
+	if len(data) > 0 && data[0] >= '0' && data[0] <= '9' {
+		ms, err := strconv.ParseUint(string(data), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		u, err := FromTimestamp(ms)
+		if err != nil {
+			return err
+		}
+
+		*id = TimestampUULID(u)
+		return nil
+	}
+
+	return json.Unmarshal(data, (*UULID)(id))
+}
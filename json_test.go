@@ -0,0 +1,104 @@
+package uulid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestVerboseUULID(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `{"id":"0178a284-9eaf-b3e7-036d-5b1b9f3cd753","time":"2021-04-05T14:51:43.663Z","entropy":"b3e7036d5b1b9f3cd753"}`
+
+	data, err := json.Marshal(uulid.VerboseUULID(id))
+	if err != nil || string(data) != expected {
+		t.Errorf("marshal error, expected: %s, got: %s, error: %s", expected, data, err)
+	}
+
+	var vid uulid.VerboseUULID
+	if err = json.Unmarshal(data, &vid); err != nil || uulid.UULID(vid).Compare(id) != 0 {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %s", id, uulid.UULID(vid), err)
+	}
+
+	vid = uulid.VerboseUULID{}
+	if err = json.Unmarshal([]byte(`"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"`), &vid); err != nil || uulid.UULID(vid).Compare(id) != 0 {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %s", id, uulid.UULID(vid), err)
+	}
+
+	if err = json.Unmarshal([]byte(`{"id":"123456789090"}`), &vid); err == nil {
+		t.Error("expected error for an invalid id")
+	}
+
+	// the default encoding remains the plain string
+	data, err = json.Marshal(id)
+	if err != nil || string(data) != `"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"` {
+		t.Errorf("marshal error, expected: %s, got: %s, error: %s", `"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"`, data, err)
+	}
+}
+
+func TestTimestampUULID(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var tid uulid.TimestampUULID
+	if err = json.Unmarshal([]byte("1617634303663"), &tid); err != nil {
+		t.Error(err)
+	}
+
+	if min := uulid.MinForTime(id.Time()); uulid.UULID(tid).Compare(min) != 0 {
+		t.Errorf("unmarshal error, expected: %s, got: %s", min, uulid.UULID(tid))
+	}
+
+	if err = json.Unmarshal([]byte(`"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"`), &tid); err != nil || uulid.UULID(tid).Compare(id) != 0 {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %s", id, uulid.UULID(tid), err)
+	}
+
+	// always encoded in the string form
+	data, err := json.Marshal(tid)
+	if err != nil || string(data) != `"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"` {
+		t.Errorf("marshal error, expected: %s, got: %s, error: %s", `"0178a284-9eaf-b3e7-036d-5b1b9f3cd753"`, data, err)
+	}
+
+	for _, data := range []string{"281474976710656", "1617634303663.5", "-1", "1e3"} {
+		if err = json.Unmarshal([]byte(data), &tid); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+func TestUULID_JSONMapKey(t *testing.T) {
+	id1, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+	id2 := uulid.NextAfter(id1)
+
+	m := map[uulid.UULID]string{id1: "a", id2: "b"}
+	expected := `{"0178a284-9eaf-b3e7-036d-5b1b9f3cd753":"a","0178a284-9eaf-b3e7-036d-5b1b9f3cd754":"b"}`
+
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != expected {
+		t.Errorf("marshal error, expected: %s, got: %s, error: %v", expected, data, err)
+	}
+
+	var parsed map[uulid.UULID]string
+	if err = json.Unmarshal(data, &parsed); err != nil {
+		t.Error(err)
+	}
+
+	if len(parsed) != 2 || parsed[id1] != "a" || parsed[id2] != "b" {
+		t.Errorf("unmarshal error, expected: %v, got: %v", m, parsed)
+	}
+
+	if err = json.Unmarshal([]byte(`{"0178a284":"a"}`), &parsed); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/brunotm/uulid/msgpack
+
+go 1.17
+
+require (
+	github.com/brunotm/uulid v0.0.0-00010101000000-000000000000
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+replace github.com/brunotm/uulid => ../
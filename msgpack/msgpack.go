@@ -0,0 +1,37 @@
+// Package msgpack provides MessagePack support for UULIDs
+// using the github.com/vmihailenco/msgpack/v5 package.
+//
+// It is a separate module, so the uulid module remains free of dependencies.
+package msgpack
+
+import (
+	"github.com/brunotm/uulid"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// UULID is an uulid.UULID that is MessagePack encoded in the 16 bytes binary form,
+// instead of the 36 characters text form, by implementing the msgpack.CustomEncoder
+// and msgpack.CustomDecoder interfaces.
+type UULID uulid.UULID
+
+// EncodeMsgpack implements the msgpack.CustomEncoder interface.
+func (id UULID) EncodeMsgpack(enc *msgpack.Encoder) (err error) {
+	return enc.EncodeBytes(id[:])
+}
+
+// DecodeMsgpack implements the msgpack.CustomDecoder interface.
+// Besides the binary form it accepts the text forms supported by uulid.Parse.
+func (id *UULID) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	data, err := dec.DecodeBytes()
+	if err != nil {
+		return err
+	}
+
+	u, err := uulid.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	*id = UULID(u)
+	return nil
+}
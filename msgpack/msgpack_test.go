@@ -0,0 +1,65 @@
+package msgpack_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/brunotm/uulid"
+	uulidmsgpack "github.com/brunotm/uulid/msgpack"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var encoded = "0178a284-9eaf-b3e7-036d-5b1b9f3cd753"
+
+func TestUULID_Msgpack(t *testing.T) {
+	id := uulidmsgpack.UULID(uulid.MustParseString(encoded))
+
+	data, err := msgpack.Marshal(id)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// bin 8 header with the 16 bytes length
+	if len(data) != 2+uulid.BinarySize {
+		t.Errorf("size error, expected: %d, got: %d", 2+uulid.BinarySize, len(data))
+	}
+
+	var parsed uulidmsgpack.UULID
+	if err = msgpack.Unmarshal(data, &parsed); err != nil || parsed != id {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %v", uulid.UULID(id), uulid.UULID(parsed), err)
+	}
+
+	// within a struct
+	type document struct {
+		ID uulidmsgpack.UULID `msgpack:"id"`
+	}
+
+	if data, err = msgpack.Marshal(document{ID: id}); err != nil {
+		t.Error(err)
+	}
+
+	var doc document
+	if err = msgpack.Unmarshal(data, &doc); err != nil || doc.ID != id {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %v", uulid.UULID(id), uulid.UULID(doc.ID), err)
+	}
+}
+
+func TestUULID_MsgpackText(t *testing.T) {
+	data, err := msgpack.Marshal(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var id uulidmsgpack.UULID
+	if err = msgpack.Unmarshal(data, &id); err != nil || uulid.UULID(id).String() != encoded {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %v", encoded, uulid.UULID(id), err)
+	}
+
+	if data, err = msgpack.Marshal([]byte("invalid")); err != nil {
+		t.Error(err)
+	}
+
+	if err = msgpack.Unmarshal(data, &id); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+}
@@ -0,0 +1,70 @@
+package uulid
+
+import "fmt"
+
+// Event is a Generator event reported to the observer set with WithObserver.
+type Event int
+
+// The Generator events.
+const (
+	// EventOverflow is reported when the entropy overflows within
+	// the same millisecond and ErrMonotonicOverflow is returned.
+	EventOverflow Event = iota + 1
+	// EventRollover is reported when the entropy overflows within the same
+	// millisecond and the Generator advances to the next millisecond
+	// as configured with WithOverflowRollover.
+	EventRollover
+	// EventClockRegression is reported when the time is behind the
+	// previous call to the Generator and ErrSmallTime is returned.
+	EventClockRegression
+
+	eventCount = iota
+)
+
+// String returns the name of the Event.
+func (e Event) String() (s string) {
+	switch e {
+	case EventOverflow:
+		return "overflow"
+	case EventRollover:
+		return "rollover"
+	case EventClockRegression:
+		return "clock regression"
+	}
+	return fmt.Sprintf("Event(%d)", int(e))
+}
+
+// WithObserver sets a function that is called for every Event of the Generator,
+// such as to count them with metrics. It is called after the Generator lock is
+// released, so it can safely use the Generator, but it may be called concurrently
+// and it delays the return of the call which caused the events.
+func WithObserver(observer func(e Event)) Option {
+	return func(r *Generator) {
+		r.observer = observer
+	}
+}
+
+// record records an event to be reported when the Generator lock is released.
+func (r *Generator) record(e Event) {
+	if r.observer != nil {
+		r.events[e-1]++
+	}
+}
+
+// unlock releases the Generator lock and reports the recorded events to the observer.
+func (r *Generator) unlock() {
+	if r.observer == nil {
+		r.mu.Unlock()
+		return
+	}
+
+	events, observer := r.events, r.observer
+	r.events = [eventCount]int{}
+	r.mu.Unlock()
+
+	for i, n := range events {
+		for ; n > 0; n-- {
+			observer(Event(i + 1))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package uulid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestGenerator_WithObserver(t *testing.T) {
+	var r *uulid.Generator
+	events := map[uulid.Event]int{}
+
+	// the observer is called without the lock held and can use the Generator
+	observer := func(e uulid.Event) {
+		events[e]++
+		_, _, _ = r.Last()
+	}
+
+	now := uulid.Time(timestamp)
+	r = uulid.NewGeneratorWithSeed(1, uulid.WithObserver(observer))
+
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+	if _, err := r.NewAt(now); err != uulid.ErrMonotonicOverflow {
+		t.Errorf("expected ErrMonotonicOverflow, got %v instead", err)
+	}
+
+	if _, err := r.NewAt(now.Add(-time.Millisecond)); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %v instead", err)
+	}
+
+	if _, err := r.NewWithEntropy(make([]byte, 10)); err != nil {
+		t.Error(err)
+	}
+
+	r = uulid.NewGeneratorWithSeed(1, uulid.WithObserver(observer), uulid.WithOverflowRollover(true))
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFE)
+	if err := r.NewNAt(now, make([]uulid.UULID, 3)); err != nil {
+		t.Error(err)
+	}
+
+	expected := map[uulid.Event]int{
+		uulid.EventOverflow:        1,
+		uulid.EventRollover:        1,
+		uulid.EventClockRegression: 1,
+	}
+
+	for e, n := range expected {
+		if events[e] != n {
+			t.Errorf("event error for %s, expected: %d, got: %d", e, n, events[e])
+		}
+	}
+
+	if s := uulid.Event(0).String(); s != "Event(0)" {
+		t.Errorf("event error, expected: Event(0), got: %s", s)
+	}
+}
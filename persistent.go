@@ -0,0 +1,96 @@
+package uulid
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// persistentStateSize is the size of the persisted Generator state,
+// the millisecond, the high and low entropy bits and the milliseconds ahead of the clock.
+const persistentStateSize = 26
+
+// NewPersistentGenerator is like NewGenerator() but persists the monotonic state
+// to the file at the given path after every UULID, and resumes from it when created,
+// so UULIDs are strictly ordered across process restarts within the same millisecond.
+// ErrSmallTime is returned if the clock is behind the persisted state.
+//
+// The state is written atomically to a temporary file that is synced and renamed
+// over the path, followed by a sync of the parent directory, which limits the generation rate to the storage sync latency.
+// ErrDataSize is returned if the file at path doesn't hold a valid state.
+func NewPersistentGenerator(path string, opts ...Option) (r *Generator, err error) {
+	if r, err = NewGenerator(opts...); err != nil {
+		return nil, err
+	}
+	r.path = path
+
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return r, nil
+	case err != nil:
+		return nil, err
+	case len(data) != persistentStateSize:
+		return nil, ErrDataSize
+	}
+
+	r.ms = binary.BigEndian.Uint64(data[0:8])
+	r.hi = binary.BigEndian.Uint16(data[8:10])
+	r.lo = binary.BigEndian.Uint64(data[10:18])
+	r.ahead = binary.BigEndian.Uint64(data[18:26])
+	return r, nil
+}
+
+// persist atomically writes the Generator state to its path.
+func (r *Generator) persist() (err error) {
+	var b [persistentStateSize]byte
+	binary.BigEndian.PutUint64(b[0:8], r.ms)
+	binary.BigEndian.PutUint16(b[8:10], r.hi)
+	binary.BigEndian.PutUint64(b[10:18], r.lo)
+	binary.BigEndian.PutUint64(b[18:26], r.ahead)
+
+	f, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(b[:]); err == nil {
+		err = f.Sync()
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err = os.Rename(f.Name(), r.path); err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(r.path))
+}
+
+// syncDir syncs the directory at path, making a rename within it durable.
+// Windows doesn't support syncing directories, so it is skipped there.
+func syncDir(path string) (err error) {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	if err = d.Sync(); err != nil {
+		_ = d.Close()
+		return err
+	}
+
+	return d.Close()
+}
@@ -0,0 +1,108 @@
+package uulid_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestNewPersistentGenerator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	tm := uulid.Time(timestamp)
+
+	r, err := uulid.NewPersistentGenerator(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev, err := r.NewAt(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// restarted generators resume from the persisted state
+	for i := 0; i < 4; i++ {
+		if r, err = uulid.NewPersistentGenerator(path); err != nil {
+			t.Fatal(err)
+		}
+
+		id, err := r.NewAt(tm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if prev.Compare(id) != -1 {
+			t.Errorf("compare error, expected: %s < %s", prev, id)
+		}
+		prev = id
+	}
+
+	if _, err = r.NewAt(tm.Add(-1)); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %v instead", err)
+	}
+
+	// no temporary files are left behind
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil || len(files) != 1 {
+		t.Errorf("expected only the state file, got: %v, error: %v", files, err)
+	}
+}
+
+func TestNewPersistentGenerator_Ahead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	tm := uulid.Time(timestamp)
+
+	r, err := uulid.NewPersistentGenerator(path, uulid.WithOverflowRollover(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uulid.SetGeneratorState(r, timestamp, 0xFFFF, 0xFFFFFFFFFFFFFFFF)
+
+	// the overflow rolls the generator over ahead of the clock
+	prev, err := r.NewAt(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// restarted generators keep accepting the clock they rolled over ahead of
+	if r, err = uulid.NewPersistentGenerator(path, uulid.WithOverflowRollover(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if s := r.State(); s.Ahead != 1 {
+		t.Errorf("ahead error, expected: %d, got: %d", 1, s.Ahead)
+	}
+
+	id, err := r.NewAt(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prev.Compare(id) != -1 {
+		t.Errorf("compare error, expected: %s < %s", prev, id)
+	}
+}
+
+func TestNewPersistentGenerator_Errors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state")
+
+	if err := os.WriteFile(path, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uulid.NewPersistentGenerator(path); err != uulid.ErrDataSize {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	r, err := uulid.NewPersistentGenerator(filepath.Join(dir, "missing", "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id, err := r.New(); err == nil || !id.IsZero() {
+		t.Errorf("expected a persist error with a zero uulid, got: %s, error: %v", id, err)
+	}
+}
@@ -0,0 +1,47 @@
+package uulid
+
+import "sync"
+
+// GeneratorPool implements an UUID generator based on the ULID spec that spreads
+// the calls across a sync.Pool of independent Generators, trading the global ordering
+// for throughput without a fixed number of shards as with ShardedGenerator.
+//
+// The generated UULIDs are only monotonically increased per pooled Generator, and
+// pooled Generators may be dropped at any time. UULIDs from different Generators
+// within the same millisecond are NOT guaranteed to be ordered.
+type GeneratorPool struct {
+	pool sync.Pool
+	opts []Option
+}
+
+// NewGeneratorPool creates a new GeneratorPool. Each pooled Generator
+// is created on demand by NewGenerator() with the given options.
+func NewGeneratorPool(opts ...Option) (p *GeneratorPool) {
+	return &GeneratorPool{opts: opts}
+}
+
+// Get returns a Generator from the pool, creating a new one if the pool is empty.
+// The Generator should be returned to the pool with Put when no longer used.
+func (p *GeneratorPool) Get() (r *Generator, err error) {
+	if r, ok := p.pool.Get().(*Generator); ok {
+		return r, nil
+	}
+	return NewGenerator(p.opts...)
+}
+
+// Put returns a Generator obtained with Get to the pool.
+func (p *GeneratorPool) Put(r *Generator) {
+	p.pool.Put(r)
+}
+
+// New creates a UULID with the current time from a pooled Generator.
+func (p *GeneratorPool) New() (id UULID, err error) {
+	r, err := p.Get()
+	if err != nil {
+		return id, err
+	}
+
+	id, err = r.New()
+	p.Put(r)
+	return id, err
+}
@@ -0,0 +1,84 @@
+package uulid_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestGeneratorPool_New(t *testing.T) {
+	p := uulid.NewGeneratorPool()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[uulid.UULID]bool)
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1024; i++ {
+				id, err := p.New()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				if id.IsZero() || seen[id] {
+					t.Errorf("duplicated or non-initialized uulid: %s", id.String())
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGeneratorPool_GetPut(t *testing.T) {
+	p := uulid.NewGeneratorPool(uulid.WithNodeID(0xCAFE))
+
+	r, err := p.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id1, err := r.New()
+	if err != nil {
+		t.Error(err)
+	}
+	p.Put(r)
+
+	// pooled generators are created with the pool options
+	if id1.NodeID() != 0xCAFE {
+		t.Errorf("node id error, expected: %d, got: %d", 0xCAFE, id1.NodeID())
+	}
+}
+
+func BenchmarkGeneratorPool_NewConcurrent(b *testing.B) {
+	b.Run("pool", func(b *testing.B) {
+		p := uulid.NewGeneratorPool()
+
+		b.ReportAllocs()
+		b.SetBytes(uulid.BinarySize)
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = p.New()
+			}
+		})
+	})
+
+	b.Run("default", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(uulid.BinarySize)
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = uulid.New()
+			}
+		})
+	})
+}
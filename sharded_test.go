@@ -0,0 +1,53 @@
+package uulid_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestShardedGenerator_New(t *testing.T) {
+	r, err := uulid.NewShardedGenerator(4)
+	if err != nil {
+		t.Error(err)
+	}
+
+	seen := make(map[uulid.UULID]bool)
+	for i := 0; i < 1024; i++ {
+		id, err := r.New()
+		if err != nil {
+			t.Error(err)
+		}
+
+		if id.IsZero() || seen[id] {
+			t.Errorf("duplicated or non-initialized uulid: %s", id.String())
+		}
+		seen[id] = true
+	}
+
+	if _, err = uulid.NewShardedGenerator(0); !errors.Is(err, uulid.ErrInvalidShards) {
+		t.Errorf("expected ErrInvalidShards, got %v instead", err)
+	}
+}
+
+func BenchmarkShardedGenerator_NewConcurrent(b *testing.B) {
+	for _, shards := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			r, err := uulid.NewShardedGenerator(shards)
+			if err != nil {
+				b.Error(err)
+			}
+
+			b.ReportAllocs()
+			b.SetBytes(uulid.BinarySize)
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, _ = r.New()
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package uulid
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Source is a source of uniformly distributed pseudo random uint64 values,
+// implemented by the math/rand/v2 sources and the *rand.Rand of both math/rand
+// and math/rand/v2.
+type Source interface {
+	Uint64() uint64
+}
+
+// NewSourceReader returns an io.Reader that fills the read buffers from the given
+// Source, so that math/rand sources can be used with NewGeneratorWithReader:
+//
+//	r := uulid.NewGeneratorWithReader(uulid.NewSourceReader(rand.NewPCG(seed1, seed2)))
+//
+// The reader is not safe for concurrent use, which is not required by the Generator.
+// As with any NewGeneratorWithReader entropy, UULIDs generated within the same
+// millisecond are NOT guaranteed to be ordered.
+func NewSourceReader(src Source) (rd io.Reader) {
+	return &sourceReader{src: src}
+}
+
+type sourceReader struct {
+	src Source
+}
+
+func (r *sourceReader) Read(p []byte) (n int, err error) {
+	for ; len(p)-n >= 8; n += 8 {
+		binary.BigEndian.PutUint64(p[n:], r.src.Uint64())
+	}
+
+	if n < len(p) {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], r.src.Uint64())
+		n += copy(p[n:], b[:])
+	}
+
+	return n, nil
+}
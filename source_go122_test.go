@@ -0,0 +1,53 @@
+//go:build go1.22
+
+package uulid_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/brunotm/uulid"
+)
+
+func ExampleNewSourceReader() {
+	r := uulid.NewGeneratorWithReader(uulid.NewSourceReader(rand.NewPCG(1, 2)))
+
+	id, err := r.NewAt(time.UnixMilli(1617634303663))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(id.Time().UTC())
+	// Output:
+	// 2021-04-05 14:51:43.663 +0000 UTC
+}
+
+func BenchmarkGenerator_Source(b *testing.B) {
+	for _, bench := range []struct {
+		name string
+		new  func() *uulid.Generator
+	}{
+		{"builtin", func() *uulid.Generator { return uulid.NewGeneratorWithSeed(1) }},
+		{"pcg", func() *uulid.Generator {
+			return uulid.NewGeneratorWithReader(uulid.NewSourceReader(rand.NewPCG(1, 2)))
+		}},
+		{"chacha8", func() *uulid.Generator {
+			return uulid.NewGeneratorWithReader(uulid.NewSourceReader(rand.NewChaCha8([32]byte{1})))
+		}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			r := bench.new()
+
+			b.ReportAllocs()
+			b.SetBytes(uulid.BinarySize)
+
+			for i := 0; i < b.N; i++ {
+				if _, err := r.New(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package uulid_test
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestNewSourceReader(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	rd := uulid.NewSourceReader(rand.New(rand.NewSource(1)))
+
+	// reads that are not a multiple of 8 bytes use a whole value
+	for _, size := range []int{8, 10, 16, 3} {
+		p := make([]byte, size)
+		if n, err := rd.Read(p); n != size || err != nil {
+			t.Errorf("read error, expected: %d, got: %d, error: %v", size, n, err)
+		}
+
+		var exp [24]byte
+		for i := 0; i < (size+7)/8; i++ {
+			binary.BigEndian.PutUint64(exp[i*8:], src.Uint64())
+		}
+
+		if string(p) != string(exp[:size]) {
+			t.Errorf("read error, expected: %x, got: %x", exp[:size], p)
+		}
+	}
+
+	r := uulid.NewGeneratorWithReader(uulid.NewSourceReader(rand.New(rand.NewSource(1))))
+	if id, err := r.New(); err != nil || id.IsZero() {
+		t.Errorf("generator error, got: %s, error: %v", id, err)
+	}
+}
@@ -0,0 +1,60 @@
+package uulid
+
+import (
+	"bufio"
+	"io"
+)
+
+// Decoder reads and parses whitespace delimited UULIDs from an input stream.
+type Decoder struct {
+	s *bufio.Scanner
+}
+
+// NewDecoder creates a new Decoder reading from r.
+func NewDecoder(r io.Reader) (d *Decoder) {
+	s := bufio.NewScanner(r)
+	s.Split(bufio.ScanWords)
+	return &Decoder{s: s}
+}
+
+// Decode reads and parses the next UULID from the input stream.
+// It returns io.EOF when the input is exhausted or the parse error
+// for an invalid UULID, in which case the decoding can be continued.
+func (d *Decoder) Decode() (id UULID, err error) {
+	if !d.s.Scan() {
+		if err = d.s.Err(); err != nil {
+			return id, err
+		}
+		return id, io.EOF
+	}
+
+	err = parse(d.s.Bytes(), &id)
+	return id, err
+}
+
+// Encoder writes newline delimited text encoded UULIDs to an output stream.
+type Encoder struct {
+	w   io.Writer
+	buf [HexEncodedSize + 1]byte
+}
+
+// NewEncoder creates a new Encoder writing to w.
+func NewEncoder(w io.Writer) (e *Encoder) {
+	e = &Encoder{w: w}
+	e.buf[HexEncodedSize] = '\n'
+	return e
+}
+
+// Encode writes the text encoding of the UULID followed by a newline.
+func (e *Encoder) Encode(id UULID) (err error) {
+	_ = id.MarshalTextTo(e.buf[:HexEncodedSize])
+	_, err = e.w.Write(e.buf[:])
+	return err
+}
+
+// EncodeBinary writes the 16 byte binary encoding of the UULID.
+func (e *Encoder) EncodeBinary(id UULID) (err error) {
+	copy(e.buf[:BinarySize], id[:])
+	_, err = e.w.Write(e.buf[:BinarySize])
+	return err
+}
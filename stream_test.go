@@ -0,0 +1,110 @@
+package uulid_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+func TestDecoder(t *testing.T) {
+	in := string(encoded) + "\n" + encodedBase32[:6] + "\n\n  " + string(encoded) + "\r\n"
+	d := uulid.NewDecoder(strings.NewReader(in))
+
+	id, err := d.Decode()
+	if err != nil || id.String() != string(encoded) {
+		t.Errorf("decode error, expected: %s, got: %s, error: %s", string(encoded), id.String(), err)
+	}
+
+	if _, err = d.Decode(); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got: %s", err)
+	}
+
+	id, err = d.Decode()
+	if err != nil || id.String() != string(encoded) {
+		t.Errorf("decode error, expected: %s, got: %s, error: %s", string(encoded), id.String(), err)
+	}
+
+	if _, err = d.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF, got: %s", err)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var buf bytes.Buffer
+	e := uulid.NewEncoder(&buf)
+
+	if err = e.Encode(id); err != nil {
+		t.Error(err)
+	}
+
+	if err = e.Encode(id); err != nil {
+		t.Error(err)
+	}
+
+	expected := string(encoded) + "\n" + string(encoded) + "\n"
+	if buf.String() != expected {
+		t.Errorf("encode error, expected: %q, got: %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err = e.EncodeBinary(id); err != nil {
+		t.Error(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), id[:]) {
+		t.Errorf("not equal: %v and %v", buf.Bytes(), id[:])
+	}
+
+	// round trip through the Decoder
+	buf.Reset()
+	_ = e.Encode(id)
+	id2, err := uulid.NewDecoder(&buf).Decode()
+	if err != nil || id.Compare(id2) != 0 {
+		t.Errorf("not equal: %s and %s, error: %s", id.String(), id2.String(), err)
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	e := uulid.NewEncoder(ioutil.Discard)
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize + 1)
+
+	for i := 0; i < b.N; i++ {
+		_ = e.Encode(id)
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	in := strings.Repeat(string(encoded)+"\n", 1024)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(in)))
+
+	for i := 0; i < b.N; i++ {
+		d := uulid.NewDecoder(strings.NewReader(in))
+		for {
+			if _, err := d.Decode(); err != nil {
+				if err != io.EOF {
+					b.Error(err)
+				}
+				break
+			}
+		}
+	}
+}
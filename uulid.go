@@ -3,9 +3,15 @@ package uulid
 
 import (
 	"bytes"
+	"crypto/sha1"
+	"crypto/subtle"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"io"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -13,6 +19,9 @@ const (
 	MaxTimestamp   = 281474976710655
 	HexEncodedSize = 36
 	BinarySize     = 16
+
+	// urnPrefix is the prefix of the UUID URN namespace format.
+	urnPrefix = "urn:uuid:"
 )
 
 var (
@@ -20,12 +29,30 @@ var (
 	// ErrBigTime is returned if the given time that is larger than MaxTimestamp.
 	ErrBigTime = errors.New("uulid: time greater than supported by the ulid spec")
 
+	// ErrChecksum is returned when parsing an UULID with a check character that doesn't match.
+	ErrChecksum = errors.New("uulid: invalid check character when parsing")
+
 	// ErrDataSize is returned when parsing an invalid string representation of a UULID.
 	ErrDataSize = errors.New("uulid: bad data size when parsing")
 
 	// ErrBufferSize is returned when marshalling an UULID to a buffer < 36 bytes.
 	ErrBufferSize = errors.New("uulid: bad buffer size when marshaling")
 
+	// ErrInvalidChar is returned when parsing data with characters outside of the encoding alphabet.
+	ErrInvalidChar = errors.New("uulid: invalid character when parsing")
+
+	// ErrInvalidFormat is returned when strictly parsing data that is not in the canonical UULID format.
+	ErrInvalidFormat = errors.New("uulid: invalid canonical format when parsing")
+
+	// ErrInvalidOption is returned when creating a Generator with incompatible options.
+	ErrInvalidOption = errors.New("uulid: incompatible generator options")
+
+	// ErrInvalidRange is returned when the start of a time range is after its end.
+	ErrInvalidRange = errors.New("uulid: start time after end time")
+
+	// ErrInvalidShards is returned when creating a ShardedGenerator with less than one shard.
+	ErrInvalidShards = errors.New("uulid: invalid number of shards")
+
 	// ErrInvalidType is returned when scan receives an invalid type.
 	ErrInvalidType = errors.New("uulid: invalid type to unmarshal")
 
@@ -33,13 +60,47 @@ var (
 	ErrMonotonicOverflow = errors.New("uulid: monotonic overflow")
 
 	// ErrSmallTime is returned if the current epoch time is lower than the previously seen
-	// by the Generator.
+	// by the Generator, or if the given time is before the Unix or Generator epoch.
 	ErrSmallTime = errors.New("uulid: time is lower than current generator")
 
 	// generator is the default Generator for the package
 	generator *Generator
 )
 
+var (
+	// Min is the smallest UULID, with all bits unset. It is equal to the zero value UULID{}.
+	Min = UULID{}
+
+	// Max is the largest UULID, with all bits set. Its timestamp is MaxTimestamp,
+	// so it is a valid UULID that round-trips through String and Parse.
+	Max = UULID{
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+	}
+)
+
+// ParseError is returned when parsing an UULID fails. It wraps the cause of the
+// failure, such as ErrDataSize, which can still be checked with errors.Is, along
+// with the input length and the position of the offending byte.
+type ParseError struct {
+	Err error // the cause of the failure
+	Len int   // the length of the input
+	Pos int   // the position of the offending byte, or -1 if not applicable
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() (s string) {
+	if e.Pos < 0 {
+		return e.Err.Error() + " (length " + strconv.Itoa(e.Len) + ")"
+	}
+	return e.Err.Error() + " (length " + strconv.Itoa(e.Len) + ", position " + strconv.Itoa(e.Pos) + ")"
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ParseError) Unwrap() (err error) {
+	return e.Err
+}
+
 func init() {
 	var err error
 	if generator, err = NewGenerator(); err != nil {
@@ -67,15 +128,170 @@ An UULID is a 16 byte Universally Unique Lexicographically Sortable Identifier
 */
 type UULID [BinarySize]byte
 
+// New creates a UULID with the current system time using the default Generator.
 func New() (id UULID, err error) {
 	return generator.New()
 }
 
+// NewWithEntropy creates a UULID with the current system time and the given
+// 10 bytes of entropy using the default Generator. See Generator.NewWithEntropy.
+func NewWithEntropy(entropy []byte) (id UULID, err error) {
+	return generator.NewWithEntropy(entropy)
+}
+
+// MustNew is like New but panics if the UULID cannot be created.
+func MustNew() (id UULID) {
+	id, err := New()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// NewString creates a UULID with the current system time using the default Generator
+// and returns its canonical 36 characters encoding.
+func NewString() (s string, err error) {
+	id, err := New()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// MustNewString is like NewString but panics if the UULID cannot be created.
+func MustNewString() (s string) {
+	return MustNew().String()
+}
+
+// FromName derives a deterministic UULID from the SHA-1 hash of the namespace and
+// name, with the version and variant fields of an RFC 4122 UUIDv5, so the same inputs
+// always yield the same UULID as other UUIDv5 implementations.
+//
+// These UULIDs are NOT time ordered and their Time() is meaningless, they are
+// meant for stable identifiers of external entities such as idempotent upserts.
+func FromName(namespace UULID, name []byte) (id UULID) {
+	h := sha1.New()
+	_, _ = h.Write(namespace[:])
+	_, _ = h.Write(name)
+
+	var sum [sha1.Size]byte
+	copy(id[:], h.Sum(sum[:0]))
+
+	id[6] = id[6]&0x0F | 0x50
+	id[8] = id[8]&0x3F | 0x80
+	return id
+}
+
+// FromTimestamp creates an UULID with the given Unix time in milliseconds and zero
+// entropy, the smallest UULID within the millisecond as with MinForTime.
+// ErrBigTime is returned if ms is greater than MaxTimestamp.
+func FromTimestamp(ms uint64) (id UULID, err error) {
+	err = id.SetTimestamp(ms)
+	return id, err
+}
+
+// FromParts creates an UULID from the given time and 10 bytes of entropy.
+// ErrBigTime is returned for times after MaxTime(), ErrSmallTime for
+// times before the Unix epoch and ErrDataSize if len(entropy) != 10.
+func FromParts(t time.Time, entropy []byte) (id UULID, err error) {
+	if err = id.SetTime(t); err != nil {
+		return UULID{}, err
+	}
+
+	if err = id.SetEntropy(entropy); err != nil {
+		return UULID{}, err
+	}
+
+	return id, nil
+}
+
+// FromUUID converts a 16 byte UUID array such as the github.com/google/uuid UUID to an UULID.
+func FromUUID(u [BinarySize]byte) (id UULID) {
+	return UULID(u)
+}
+
+// ToArray returns the UULID as a 16 byte array, which can be converted
+// to other UUID types such as the github.com/google/uuid UUID.
+func (id UULID) ToArray() (u [BinarySize]byte) {
+	return id
+}
+
+// FromULIDBytes converts the 16 byte array of a ULID such as the github.com/oklog/ulid ULID to an UULID.
+// The binary layout of a 48 bit millisecond timestamp followed by 80 bits of entropy
+// is the same for both, so the time and entropy semantics are preserved.
+func FromULIDBytes(b [BinarySize]byte) (id UULID) {
+	return UULID(b)
+}
+
+// ULIDBytes returns the UULID as a 16 byte array with the ULID binary layout,
+// which can be converted to other ULID types such as the github.com/oklog/ulid ULID.
+func (id UULID) ULIDBytes() (b [BinarySize]byte) {
+	return id
+}
+
 // Time returns the UULID time component with a millisecond precision
+// in the local time zone. Use TimeUTC for a time that formats the
+// same regardless of the machine time zone.
 func (id UULID) Time() time.Time {
 	return Time(id.Timestamp())
 }
 
+// TimeUTC returns the UULID time component with a millisecond precision in UTC.
+func (id UULID) TimeUTC() (t time.Time) {
+	return id.Time().UTC()
+}
+
+// TimeIn returns the UULID time component with a millisecond precision
+// in the given location. It panics if loc is nil, as time.Time.In.
+func (id UULID) TimeIn(loc *time.Location) (t time.Time) {
+	return id.Time().In(loc)
+}
+
+// EpochTime returns the UULID time component with a millisecond precision
+// for UULIDs created by a Generator using WithEpoch with the given epoch.
+func (id UULID) EpochTime(epoch time.Time) (t time.Time) {
+	return EpochTime(id.Timestamp(), epoch)
+}
+
+// MicroTime returns the UULID time component with a microsecond precision
+// for UULIDs created by a Generator using WithSubMillisecondTime.
+// For other UULIDs the sub millisecond time is derived from random entropy.
+func (id UULID) MicroTime() (t time.Time) {
+	us := int64(id[6])<<2 | int64(id[7])>>6
+	if us > 999 {
+		us = 999
+	}
+	return id.Time().Add(time.Duration(us) * time.Microsecond)
+}
+
+// NodeID returns the 16 most significant bits of the entropy, which holds the
+// node identifier for UULIDs created by a Generator using WithNodeID.
+func (id UULID) NodeID() (n uint16) {
+	return uint16(id[6])<<8 | uint16(id[7])
+}
+
+// Counter returns the 64 least significant bits of the entropy, the monotonic
+// counter incremented by the Generator within the same millisecond from a random
+// start. The 16 most significant bits in id[6:8] are random for each millisecond and
+// only incremented when the counter overflows.
+func (id UULID) Counter() (c uint64) {
+	return binary.BigEndian.Uint64(id[8:])
+}
+
+// UUIDVersion returns the RFC 4122 version field of the UULID,
+// which is 7 or 8 for UULIDs created by a Generator using WithUUIDVersion7 or WithUUIDVersion8,
+// and 5 for UULIDs derived with FromName.
+// The field is random for UULIDs created without a UUID version.
+func (id UULID) UUIDVersion() (v byte) {
+	return id[6] >> 4
+}
+
+// IsUUIDv7 returns true if the UULID has the version and variant fields
+// of an RFC 9562 UUIDv7, as created by a Generator using WithUUIDVersion7.
+func (id UULID) IsUUIDv7() (ok bool) {
+	return id.UUIDVersion() == 7 && id[8]&0xC0 == 0x80
+}
+
 // Timestamp return the UULID millisecond unix timestamp
 func (id UULID) Timestamp() uint64 {
 	// Adapted from binary.BigEndian.Uint64 to 6 byte
@@ -88,6 +304,45 @@ func (id UULID) Timestamp() uint64 {
 
 }
 
+// Age returns the time elapsed since the UULID time.
+func (id UULID) Age() (d time.Duration) {
+	return time.Since(id.Time())
+}
+
+// Before returns true if the id timestamp is before the other timestamp.
+// Unlike Compare, it ignores the entropy so UULIDs within the same
+// millisecond are neither before nor after each other.
+func (id UULID) Before(other UULID) (ok bool) {
+	return id.Timestamp() < other.Timestamp()
+}
+
+// After returns true if the id timestamp is after the other timestamp.
+// Unlike Compare, it ignores the entropy so UULIDs within the same
+// millisecond are neither before nor after each other.
+func (id UULID) After(other UULID) (ok bool) {
+	return id.Timestamp() > other.Timestamp()
+}
+
+// SameMillisecond returns true if both UULIDs have the same timestamp,
+// such as for grouping UULIDs into millisecond buckets.
+func (id UULID) SameMillisecond(other UULID) (ok bool) {
+	return id.Timestamp() == other.Timestamp()
+}
+
+// Between returns true if id sorts within the inclusive [start, end] range by Compare.
+// Together with MinForTime and MaxForTime it checks the membership in a time window.
+// It returns false if start > end.
+func (id UULID) Between(start, end UULID) (ok bool) {
+	return id.Compare(start) >= 0 && id.Compare(end) <= 0
+}
+
+// CompareTime returns an integer comparing only the timestamp of the UULIDs,
+// ignoring the entropy. The result will be 0 if both are within the same
+// millisecond, -1 if id is before other, and +1 if id is after other.
+func (id UULID) CompareTime(other UULID) (i int) {
+	return bytes.Compare(id[:6], other[:6])
+}
+
 // SetTimestamp sets the time component of the ULID to the given Unix time
 // in milliseconds.
 func (id *UULID) SetTimestamp(ms uint64) (err error) {
@@ -106,8 +361,14 @@ func (id *UULID) SetTimestamp(ms uint64) (err error) {
 }
 
 // SetTime sets the time component of the ULID to the given time.Time.
+// ErrBigTime is returned for times after MaxTime() and
+// ErrSmallTime for times before the Unix epoch.
 func (id *UULID) SetTime(t time.Time) (err error) {
-	return id.SetTimestamp(Timestamp(t))
+	ms, err := timestamp(t)
+	if err != nil {
+		return err
+	}
+	return id.SetTimestamp(ms)
 }
 
 // SetEntropy sets the ULID entropy to the passed byte slice.
@@ -120,17 +381,78 @@ func (id *UULID) SetEntropy(e []byte) (err error) {
 	return nil
 }
 
+// SetCounter sets the ULID entropy to the given high and low bits in the Generator
+// layout, hi in the 16 most significant bits and lo in the 64 bit monotonic counter
+// returned by Counter().
+func (id *UULID) SetCounter(hi uint16, lo uint64) {
+	binary.BigEndian.PutUint16(id[6:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+}
+
 // Compare returns an integer comparing id and other lexicographically.
 // The result will be 0 if id==other, -1 if id < other, and +1 if id > other.
 func (id UULID) Compare(other UULID) (i int) {
 	return bytes.Compare(id[:], other[:])
 }
 
-// String returns the string encoded UULID
-func (id *UULID) String() (s string) {
-	b := make([]byte, HexEncodedSize)
-	id.MarshalTextTo(b)
-	return string(b)
+// Clone returns a copy of the UULID.
+//
+// As an UULID is an array, assignments and value receivers already copy it.
+// Clone makes the copy explicit when the UULID is held through a pointer,
+// like a *UULID obtained from a struct or slice element.
+func (id UULID) Clone() (c UULID) {
+	return id
+}
+
+// IsZero returns true if the UULID is the zero value.
+func (id UULID) IsZero() (ok bool) {
+	return id == UULID{}
+}
+
+// Equal returns true if id and other are the same UULID.
+func (id UULID) Equal(other UULID) (ok bool) {
+	return id == other
+}
+
+// EqualConstantTime is like Equal but takes a constant time, independent of the
+// contents of the UULIDs, using crypto/subtle. It is only meant for equality checks
+// on UULIDs used as secrets such as capability tokens, use Compare for ordering.
+func (id UULID) EqualConstantTime(other UULID) (ok bool) {
+	return subtle.ConstantTimeCompare(id[:], other[:]) == 1
+}
+
+// String returns the string encoded UULID.
+// It implements the fmt.Stringer interface.
+//
+// String allocates only the returned string. For zero allocations append
+// the text encoding to a reusable buffer with AppendText or MarshalTextTo.
+func (id UULID) String() (s string) {
+	var b [HexEncodedSize]byte
+	_ = id.MarshalTextTo(b[:])
+	return string(b[:])
+}
+
+// StringUpper is like String but returns the
+// upper case hex 0178A284-9EAF-B3E7-036D-5B1B9F3CD753 format.
+func (id UULID) StringUpper() (s string) {
+	var b [HexEncodedSize]byte
+	_ = id.MarshalTextTo(b[:])
+	toUpper(b[:])
+	return string(b[:])
+}
+
+// Hex returns the 32 characters hex 0178a2849eafb3e7036d5b1b9f3cd753
+// format without dashes, as stored in CHAR(32) columns.
+func (id UULID) Hex() (s string) {
+	var b [BinarySize * 2]byte
+	hex.Encode(b[:], id[:])
+	return string(b[:])
+}
+
+// Set parses the given string into the UULID.
+// Together with String it implements the flag.Value interface.
+func (id *UULID) Set(s string) (err error) {
+	return parseString(s, id)
 }
 
 // MarshalBinaryTo writes the binary encoding of the ULID to the given buffer.
@@ -146,19 +468,56 @@ func (id UULID) MarshalBinaryTo(dst []byte) (err error) {
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface
 func (id UULID) MarshalBinary() (data []byte, err error) {
-	data = make([]byte, BinarySize)
-	return data, id.MarshalBinaryTo(data)
+	return id.AppendBinary(make([]byte, 0, BinarySize))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+// It appends the binary encoding of the UULID to b.
+func (id UULID) AppendBinary(b []byte) (data []byte, err error) {
+	return append(b, id[:]...), nil
+}
+
+// WriteTo implements the io.WriterTo interface.
+// It writes the 16 byte binary encoding of the UULID to w.
+func (id UULID) WriteTo(w io.Writer) (n int64, err error) {
+	c, err := w.Write(id[:])
+	return int64(c), err
+}
+
+// ReadFrom implements the io.ReaderFrom interface.
+// It reads exactly the 16 byte binary encoding of an UULID from r.
+//
+// io.EOF is returned if no bytes were read and io.ErrUnexpectedEOF
+// on a short read, in which case the UULID is not modified.
+func (id *UULID) ReadFrom(r io.Reader) (n int64, err error) {
+	var buf [BinarySize]byte
+	c, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		return int64(c), err
+	}
+
+	return int64(c), parse(buf[:], id)
 }
 
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 func (id *UULID) UnmarshalBinary(data []byte) (err error) {
 	if len(data) != BinarySize {
-		return ErrDataSize
+		return &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
 	}
 
 	return parse(data, id)
 }
 
+// GobEncode implements the gob.GobEncoder interface using the binary encoding.
+func (id UULID) GobEncode() (data []byte, err error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface using the binary encoding.
+func (id *UULID) GobDecode(data []byte) (err error) {
+	return id.UnmarshalBinary(data)
+}
+
 // MarshalTextTo writes the UULID as a string to the given buffer.
 // ErrBufferSize is returned when the len(dst) != EncodedSize.
 func (id UULID) MarshalTextTo(dst []byte) (err error) {
@@ -180,8 +539,23 @@ func (id UULID) MarshalTextTo(dst []byte) (err error) {
 
 // MarshalText implements the encoding.TextMarshaler interface.
 func (id UULID) MarshalText() (data []byte, err error) {
-	data = make([]byte, HexEncodedSize)
-	return data, id.MarshalTextTo(data)
+	return id.AppendText(make([]byte, 0, HexEncodedSize))
+}
+
+// AppendText implements the encoding.TextAppender interface.
+// It appends the text encoding of the UULID to b.
+func (id UULID) AppendText(b []byte) (data []byte, err error) {
+	n := len(b)
+	b = append(b, make([]byte, HexEncodedSize)...)
+	return b, id.MarshalTextTo(b[n:])
+}
+
+// AppendToBuilder appends the text encoding of the UULID to the builder,
+// without allocating beyond the growth of the builder buffer.
+func (id UULID) AppendToBuilder(b *strings.Builder) {
+	var buf [HexEncodedSize]byte
+	_ = id.MarshalTextTo(buf[:])
+	_, _ = b.Write(buf[:])
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
@@ -190,15 +564,31 @@ func (id *UULID) UnmarshalText(data []byte) (err error) {
 }
 
 // Scan implements the sql.Scanner interface.
-// It supports scanning a string or byte slice.
+// It supports scanning a string, byte slice or a [16]byte array in any
+// of the formats supported by Parse, including the ULID Base32 format.
+// A sql/driver.Valuer, such as a wrapper type from another package,
+// is scanned from the value returned by its Value method.
 func (id *UULID) Scan(src interface{}) (err error) {
 	switch x := src.(type) {
 	case nil:
 		return nil
 	case string:
-		return parse([]byte(x), id)
+		return parseString(x, id)
 	case []byte:
 		return parse(x, id)
+	case [BinarySize]byte:
+		return parse(x[:], id)
+	case driver.Valuer:
+		v, err := x.Value()
+		if err != nil {
+			return err
+		}
+
+		// a driver.Value must not be a Valuer itself, which also prevents an unbounded recursion
+		if _, ok := v.(driver.Valuer); ok {
+			return ErrInvalidType
+		}
+		return id.Scan(v)
 	}
 
 	return ErrInvalidType
@@ -210,6 +600,56 @@ func (id UULID) Value() (v driver.Value, err error) {
 	return string(b), err
 }
 
+// GormDataType returns the uuid column data type for the GORM ORM, so UULIDs can be
+// used as primary keys without migration hints. With the text sql/driver.Valuer, the
+// canonical text form is converted by the PostgreSQL uuid type.
+func (UULID) GormDataType() (t string) {
+	return "uuid"
+}
+
+// BinaryUULID is an UULID that uses its 16 byte binary encoding when used with
+// the sql/driver.Valuer interface. It is meant for binary column types such as
+// the PostgreSQL uuid or MySQL BINARY(16), while UULID is meant for text column
+// types such as CHAR(36).
+//
+// An UULID is converted to a BinaryUULID and back with a type conversion:
+//
+//	bid := uulid.BinaryUULID(id)
+//	id = uulid.UULID(bid)
+type BinaryUULID UULID
+
+// Scan implements the sql.Scanner interface.
+// It supports scanning a 16 byte binary, [16]byte array or the text encoded UULID.
+func (id *BinaryUULID) Scan(src interface{}) (err error) {
+	return (*UULID)(id).Scan(src)
+}
+
+// Value implements the sql/driver.Valuer using the 16 byte binary encoding.
+func (id BinaryUULID) Value() (v driver.Value, err error) {
+	return UULID(id).MarshalBinary()
+}
+
+// Bytes returns a copy of the 16 byte binary UULID.
+// Use MarshalBinaryTo to write it to an existing buffer without allocating.
+func (id UULID) Bytes() (data []byte) {
+	data = make([]byte, BinarySize)
+	copy(data, id[:])
+	return data
+}
+
+// ProtoBytes returns a 16 bytes copy of the UULID for protobuf bytes fields.
+// It can be converted back with FromProtoBytes.
+func (id UULID) ProtoBytes() (data []byte) {
+	return id.Bytes()
+}
+
+// FromProtoBytes converts a protobuf bytes field created by ProtoBytes to an UULID.
+// ErrDataSize is returned if the length is different from BinarySize.
+func FromProtoBytes(b []byte) (id UULID, err error) {
+	err = id.UnmarshalBinary(b)
+	return id, err
+}
+
 // Entropy returns the entropy from the UULID.
 func (id UULID) Entropy() (data []byte) {
 	data = make([]byte, 10)
@@ -217,10 +657,41 @@ func (id UULID) Entropy() (data []byte) {
 	return data
 }
 
+// EntropyTo copies the 10 bytes of entropy from the UULID to the given buffer.
+// ErrBufferSize is returned when the len(dst) < 10.
+func (id UULID) EntropyTo(dst []byte) (err error) {
+	if len(dst) < 10 {
+		return ErrBufferSize
+	}
+
+	copy(dst, id[6:])
+	return nil
+}
+
+// TimeBytes returns a copy of the 6 bytes big endian timestamp from the UULID.
+func (id UULID) TimeBytes() (b [6]byte) {
+	copy(b[:], id[:6])
+	return b
+}
+
+// SetTimeBytes sets the 6 bytes big endian timestamp of the UULID.
+// Every 6 bytes value is a valid timestamp up to MaxTimestamp.
+func (id *UULID) SetTimeBytes(b [6]byte) {
+	copy(id[:6], b[:])
+}
+
 // Parse parses an encoded UULID, returning an error in case of failure.
+// Besides the binary, 32 and 36 characters formats it accepts the braced
+// {0178a284-9eaf-b3e7-036d-5b1b9f3cd753} and urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753 formats,
+// and the 26 characters ULID Crockford's Base32 01F2H897NFPFKG6VAV3EFKSNTK format.
+//
+// Errors are returned as a *ParseError wrapping the cause of the failure:
 //
 // ErrDataSize is returned if the length is different from an encoded
-// UULID valid lengths, either 32 or 36 characters.
+// UULID valid lengths, either 16, 26, 32, 36, 38 or 45 characters.
+//
+// ErrInvalidChar is returned if the data contains non hex characters,
+// or characters outside of the Crockford's Base32 alphabet.
 //
 // ErrBigTime is returned if time is greater than MaxTime().
 func Parse(data []byte) (id UULID, err error) {
@@ -228,55 +699,370 @@ func Parse(data []byte) (id UULID, err error) {
 	return id, err
 }
 
+// ParseString is like Parse but takes a string.
+// It avoids allocating a copy of the string for the conversion to bytes.
+func ParseString(s string) (id UULID, err error) {
+	err = parseString(s, &id)
+	return id, err
+}
+
+// ParseTo is like Parse but decodes the data in place into dst, avoiding
+// the copy of the returned value when decoding into existing values.
+// The contents of dst are undefined on failure.
+func ParseTo(data []byte, dst *UULID) (err error) {
+	return parse(data, dst)
+}
+
+// ParseHex is like Parse but only accepts the 32 characters hex format
+// without dashes, returning a *ParseError wrapping ErrDataSize otherwise.
+func ParseHex(data []byte) (id UULID, err error) {
+	if len(data) != BinarySize*2 {
+		return UULID{}, &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
+	}
+
+	if err = parse(data, &id); err != nil {
+		return UULID{}, err
+	}
+	return id, nil
+}
+
+// ParseLenient is like ParseHex but skips any non hex characters in data, such
+// as dashes, spaces or underscores, as long as exactly 32 hex characters remain.
+// A *ParseError wrapping ErrDataSize is returned otherwise. As it accepts
+// malformed input, prefer Parse unless the input formatting is inconsistent.
+func ParseLenient(data []byte) (id UULID, err error) {
+	var b [BinarySize * 2]byte
+	n := 0
+
+	for _, c := range data {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			continue
+		}
+
+		if n == len(b) {
+			return UULID{}, &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
+		}
+		b[n] = c
+		n++
+	}
+
+	if n != len(b) {
+		return UULID{}, &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
+	}
+
+	if err = parse(b[:], &id); err != nil {
+		return UULID{}, err
+	}
+	return id, nil
+}
+
+// Valid returns true if data is an UULID in any of the formats supported by Parse.
+func Valid(data []byte) (ok bool) {
+	var id UULID
+	return parse(data, &id) == nil
+}
+
+// ValidString is like Valid but takes a string.
+func ValidString(s string) (ok bool) {
+	var id UULID
+	return parseString(s, &id) == nil
+}
+
+// ParseStrict parses an UULID only in the canonical 36 characters format
+// with lower case hex characters and dashes at the standard UUID positions,
+// the same format returned by String().
+//
+// Errors are returned as a *ParseError wrapping the cause of the failure:
+//
+// ErrDataSize is returned if the length is different from HexEncodedSize.
+//
+// ErrInvalidFormat is returned if data is not in the canonical format.
+func ParseStrict(data []byte) (id UULID, err error) {
+	if len(data) != HexEncodedSize {
+		return id, &ParseError{Err: ErrDataSize, Len: len(data), Pos: -1}
+	}
+
+	for i, c := range data {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return id, &ParseError{Err: ErrInvalidFormat, Len: len(data), Pos: i}
+			}
+		default:
+			if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+				return id, &ParseError{Err: ErrInvalidFormat, Len: len(data), Pos: i}
+			}
+		}
+	}
+
+	err = parse(data, &id)
+	return id, err
+}
+
+// MustParse is like Parse but panics if the data cannot be parsed.
+// It simplifies the safe initialization of global variables holding UULIDs.
+func MustParse(data []byte) (id UULID) {
+	id, err := Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// MustParseString is like MustParse but takes a string.
+func MustParseString(s string) (id UULID) {
+	id, err := ParseString(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// parseString parses s through a stack buffer large
+// enough for the longest format supported by parse.
+func parseString(s string, id *UULID) (err error) {
+	var b [len(urnPrefix) + HexEncodedSize]byte
+	if len(s) > len(b) {
+		return &ParseError{Err: ErrDataSize, Len: len(s), Pos: -1}
+	}
+
+	n := copy(b[:], s)
+	return parse(b[:n], id)
+}
+
 func parse(data []byte, id *UULID) (err error) {
+	n, off := len(data), 0
+
+	// strip the braced {0177de6a-6f3d-d1d5-f5f7-d0c250314de9}
+	// and urn:uuid:0177de6a-6f3d-d1d5-f5f7-d0c250314de9 formats
+	switch {
+	case len(data) == HexEncodedSize+2 && data[0] == '{' && data[len(data)-1] == '}':
+		data, off = data[1:len(data)-1], 1
+	case len(data) == len(urnPrefix)+HexEncodedSize && bytes.EqualFold(data[:len(urnPrefix)], []byte(urnPrefix)):
+		data, off = data[len(urnPrefix):], len(urnPrefix)
+	}
+
 	switch len(data) {
 	case 16: // binary encoded
 		copy(id[:], data)
 
+	case Base32EncodedSize: // ULID Crockford's Base32 format 01F2H897NFPFKG6VAV3EFKSNTK
+		return parseBase32(data, id)
+
 	case 32: // UUID hex format 0177de6a6f3dd1d5f5f7d0c250314de9
-		if _, err = hex.Decode(id[:], data); err != nil {
-			return err
+		if pos, err := decodeHex(id[:], data); err != nil {
+			return &ParseError{Err: err, Len: n, Pos: off + pos}
 		}
 
 	case 36: // UUID standard format 0177de6a-6f3d-d1d5-f5f7-d0c250314de9
-		if _, err := hex.Decode(id[0:4], data[0:8]); err != nil {
-			return err
+		if decodeUUID(id, data) {
+			break
 		}
-		if _, err := hex.Decode(id[4:6], data[9:13]); err != nil {
-			return err
+
+		// fallback for other separators and for reporting the invalid characters
+		if pos, err := decodeHex(id[0:4], data[0:8]); err != nil {
+			return &ParseError{Err: err, Len: n, Pos: off + pos}
 		}
-		if _, err := hex.Decode(id[6:8], data[14:18]); err != nil {
-			return err
+		if pos, err := decodeHex(id[4:6], data[9:13]); err != nil {
+			return &ParseError{Err: err, Len: n, Pos: off + 9 + pos}
 		}
-		if _, err := hex.Decode(id[8:10], data[19:23]); err != nil {
-			return err
+		if pos, err := decodeHex(id[6:8], data[14:18]); err != nil {
+			return &ParseError{Err: err, Len: n, Pos: off + 14 + pos}
 		}
-		if _, err := hex.Decode(id[10:16], data[24:36]); err != nil {
-			return err
+		if pos, err := decodeHex(id[8:10], data[19:23]); err != nil {
+			return &ParseError{Err: err, Len: n, Pos: off + 19 + pos}
+		}
+		if pos, err := decodeHex(id[10:16], data[24:36]); err != nil {
+			return &ParseError{Err: err, Len: n, Pos: off + 24 + pos}
 		}
 
 	default:
-		return ErrDataSize
+		return &ParseError{Err: ErrDataSize, Len: n, Pos: -1}
 	}
 
 	if id.Timestamp() > MaxTimestamp {
-		return ErrBigTime
+		return &ParseError{Err: ErrBigTime, Len: n, Pos: -1}
 	}
 
 	return nil
 }
 
+// hexDec maps a byte to its hex value or 0xFF if invalid.
+var hexDec = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xFF
+	}
+
+	for i := 0; i < 10; i++ {
+		t['0'+i] = byte(i)
+	}
+
+	for i := 0; i < 6; i++ {
+		t['a'+i], t['A'+i] = byte(10+i), byte(10+i)
+	}
+
+	return t
+}()
+
+// uuidOffsets are the positions of the hex byte pairs in the 36 characters UUID format.
+var uuidOffsets = [BinarySize]byte{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34}
+
+// decodeUUID is the fast path decoding of the 36 characters UUID format with dashes
+// through the hexDec table. It returns false for any other separators or invalid hex
+// characters, leaving dst with undefined contents.
+func decodeUUID(dst *UULID, src []byte) (ok bool) {
+	if len(src) != HexEncodedSize || src[8] != '-' || src[13] != '-' || src[18] != '-' || src[23] != '-' {
+		return false
+	}
+
+	// invalid characters have the high bits set in the table
+	var invalid byte
+	for i, o := range uuidOffsets {
+		hi, lo := hexDec[src[o]], hexDec[src[o+1]]
+		invalid |= hi | lo
+		dst[i] = hi<<4 | lo
+	}
+
+	return invalid&0xF0 == 0
+}
+
+// decodeHex decodes the hex src into dst, returning ErrInvalidChar
+// and the position of the first invalid character within src on failure.
+func decodeHex(dst, src []byte) (pos int, err error) {
+	if _, err = hex.Decode(dst, src); err != nil {
+		for pos = range src {
+			if c := src[pos]; (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+				break
+			}
+		}
+		return pos, ErrInvalidChar
+	}
+	return 0, nil
+}
+
 // Timestamp converts a time.Time to Unix milliseconds.
-// Times from the year 10889 produces undefined results.
+// Times after MaxTime() saturate at MaxTimestamp and
+// times before the Unix epoch are clamped to zero.
 func Timestamp(t time.Time) (ms uint64) {
-	return uint64(t.Unix())*1000 +
-		uint64(t.Nanosecond()/int(time.Millisecond))
+	ms, _ = timestamp(t)
+	return ms
+}
+
+// timestamp is like Timestamp but returns ErrBigTime along with MaxTimestamp
+// for times after MaxTime(), and ErrSmallTime along with zero for times before
+// the Unix epoch.
+func timestamp(t time.Time) (ms uint64, err error) {
+	// check the seconds first, as UnixMilli overflows
+	// for times far beyond MaxTime()
+	switch sec := t.Unix(); {
+	case sec < 0:
+		return 0, ErrSmallTime
+	case sec > MaxTimestamp/1000:
+		return MaxTimestamp, ErrBigTime
+	}
+
+	if ms = uint64(t.UnixMilli()); ms > MaxTimestamp {
+		return MaxTimestamp, ErrBigTime
+	}
+
+	return ms, nil
 }
 
 // Time converts Unix milliseconds in the format
 // returned by the Timestamp function to a time.Time.
+// Milliseconds greater than MaxTimestamp saturate at MaxTime().
 func Time(ms uint64) (t time.Time) {
-	return time.Unix(int64(ms/1e3), int64((ms%1e3)*1e6))
+	if ms > MaxTimestamp {
+		ms = MaxTimestamp
+	}
+	return time.UnixMilli(int64(ms))
+}
+
+// EpochTimestamp converts a time.Time to milliseconds since the given epoch,
+// the timestamp stored by a Generator using WithEpoch.
+// Times before the epoch saturate at 0, and times after MaxTimestamp
+// milliseconds from the epoch saturate at MaxTimestamp.
+func EpochTimestamp(t, epoch time.Time) (ms uint64) {
+	ms, _ = epochTimestamp(t, epoch)
+	return ms
+}
+
+// epochTimestamp is like EpochTimestamp but returns ErrSmallTime
+// and ErrBigTime along with the saturated values.
+func epochTimestamp(t, epoch time.Time) (ms uint64, err error) {
+	if t.Before(epoch) {
+		return 0, ErrSmallTime
+	}
+
+	sec := uint64(t.Unix() - epoch.Unix())
+	if sec > MaxTimestamp/1000+1 {
+		return MaxTimestamp, ErrBigTime
+	}
+
+	ms = sec*1000 + uint64(t.Nanosecond()/int(time.Millisecond)) -
+		uint64(epoch.Nanosecond()/int(time.Millisecond))
+	if ms > MaxTimestamp {
+		return MaxTimestamp, ErrBigTime
+	}
+
+	return ms, nil
+}
+
+// EpochTime converts milliseconds since the given epoch in the format
+// returned by the EpochTimestamp function to a time.Time.
+// Milliseconds greater than MaxTimestamp saturate at MaxTimestamp.
+func EpochTime(ms uint64, epoch time.Time) (t time.Time) {
+	if ms > MaxTimestamp {
+		ms = MaxTimestamp
+	}
+
+	return time.Unix(epoch.Unix()+int64(ms/1e3),
+		int64(epoch.Nanosecond()/int(time.Millisecond))*1e6+int64((ms%1e3)*1e6))
+}
+
+// MinForTime returns the smallest UULID for the given time, with all entropy bits unset.
+// Together with MaxForTime it provides the inclusive bounds of the UULIDs within the
+// millisecond of the given time. Times after MaxTime() are clamped to MaxTime()
+// and times before the Unix epoch to the Unix epoch.
+func MinForTime(t time.Time) (id UULID) {
+	_ = id.SetTimestamp(Timestamp(t))
+	return id
+}
+
+// MaxForTime returns the largest UULID for the given time, with all entropy bits set.
+// Times are clamped as in MinForTime.
+func MaxForTime(t time.Time) (id UULID) {
+	id = MinForTime(t)
+	for i := 6; i < BinarySize; i++ {
+		id[i] = 0xFF
+	}
+	return id
+}
+
+// NextAfter returns the smallest UULID strictly greater than id, incrementing its
+// 128 bits value by one, which carries over from the entropy into the timestamp.
+// It saturates at the largest UULID, with all bits set, which is returned unchanged.
+func NextAfter(id UULID) (next UULID) {
+	next = id
+	for i := BinarySize - 1; i >= 0; i-- {
+		if next[i]++; next[i] != 0 {
+			return next
+		}
+	}
+	return id
+}
+
+// PrevBefore returns the largest UULID strictly less than id, decrementing its
+// 128 bits value by one, which borrows from the timestamp into the entropy.
+// It saturates at the zero UULID, which is returned unchanged.
+func PrevBefore(id UULID) (prev UULID) {
+	prev = id
+	for i := BinarySize - 1; i >= 0; i-- {
+		if prev[i]--; prev[i] != 0xFF {
+			return prev
+		}
+	}
+	return id
 }
 
 // MaxTime returns the maximum time supported by an UULID
@@ -2,7 +2,15 @@ package uulid_test
 
 import (
 	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
 	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,6 +51,41 @@ func TestUULID_SetTimestamp(t *testing.T) {
 	}
 }
 
+func TestUULID_TimeIn(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	expected := "2021-04-05T16:51:43.663+02:00"
+
+	tm := id.TimeIn(loc)
+	if s := tm.Format("2006-01-02T15:04:05.000Z07:00"); s != expected {
+		t.Errorf("time error, expected: %s, got: %s", expected, s)
+	}
+
+	if !tm.Equal(id.Time()) || tm.Location() != loc {
+		t.Errorf("time error, expected: %s, got: %s", id.Time().In(loc), tm)
+	}
+}
+
+func TestUULID_TimeUTC(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tm := id.TimeUTC()
+	if expected := "2021-04-05T14:51:43.663Z"; tm.Format("2006-01-02T15:04:05.000Z07:00") != expected {
+		t.Errorf("time error, expected: %s, got: %s", expected, tm.Format("2006-01-02T15:04:05.000Z07:00"))
+	}
+
+	if !tm.Equal(id.Time()) || tm.Location() != time.UTC {
+		t.Errorf("time error, expected: %s, got: %s", id.Time().UTC(), tm)
+	}
+}
+
 func TestUULID_String(t *testing.T) {
 	id, err := uulid.Parse(encoded)
 	if err != nil {
@@ -55,6 +98,169 @@ func TestUULID_String(t *testing.T) {
 
 }
 
+func TestUULID_StringUpper(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "0178A284-9EAF-B3E7-036D-5B1B9F3CD753"
+	if s := id.StringUpper(); s != expected {
+		t.Errorf("format error, expected: %s, got: %s", expected, s)
+	}
+
+	parsed, err := uulid.ParseString(id.StringUpper())
+	if err != nil || parsed.Compare(id) != 0 {
+		t.Errorf("parse error, expected: %s, got: %s, error: %v", id, parsed, err)
+	}
+}
+
+func TestUULID_Hex(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "0178a2849eafb3e7036d5b1b9f3cd753"
+	if s := id.Hex(); s != expected {
+		t.Errorf("format error, expected: %s, got: %s", expected, s)
+	}
+
+	parsed, err := uulid.ParseHex([]byte(id.Hex()))
+	if err != nil || parsed.Compare(id) != 0 {
+		t.Errorf("parse error, expected: %s, got: %s, error: %v", id, parsed, err)
+	}
+
+	if _, err = uulid.ParseHex(encoded); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	if _, err = uulid.ParseHex(id[:]); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	var pe *uulid.ParseError
+	if _, err = uulid.ParseHex([]byte("0178a2849eafb3e7036d5b1b9f3cd75g")); !errors.As(err, &pe) || pe.Err != uulid.ErrInvalidChar || pe.Pos != 31 {
+		t.Errorf("expected ErrInvalidChar at 31, got %v instead", err)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	for _, data := range []string{
+		"0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+		"0178a284 9eaf b3e7 036d 5b1b9f3cd753",
+		"0178a284_9eaf_b3e7_036d_5b1b9f3cd753",
+		" 0178A284-9EAF_b3e7 036d:5b1b9f3cd753\n",
+		"0178a2849eafb3e7036d5b1b9f3cd753",
+	} {
+		id, err := uulid.ParseLenient([]byte(data))
+		if err != nil || id.String() != string(encoded) {
+			t.Errorf("parse error for %q, expected: %s, got: %s, error: %v", data, encoded, id, err)
+		}
+	}
+
+	for _, data := range []string{
+		"",
+		"0178a284-9eaf-b3e7-036d-5b1b9f3cd75",
+		"0178a284-9eaf-b3e7-036d-5b1b9f3cd7531",
+		"urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+	} {
+		if _, err := uulid.ParseLenient([]byte(data)); !errors.Is(err, uulid.ErrDataSize) {
+			t.Errorf("expected ErrDataSize for %q, got %v instead", data, err)
+		}
+	}
+}
+
+func TestUULID_Stringer(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if s := fmt.Sprintf("%s", id); s != string(encoded) {
+		t.Errorf("format error, expected: %s, got: %s", string(encoded), s)
+	}
+
+	if s := fmt.Sprintf("%v", id); s != string(encoded) {
+		t.Errorf("format error, expected: %s, got: %s", string(encoded), s)
+	}
+
+	if s := fmt.Sprint(&id); s != string(encoded) {
+		t.Errorf("format error, expected: %s, got: %s", string(encoded), s)
+	}
+}
+
+func TestUULID_Bytes(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b := id.Bytes()
+	if !bytes.Equal(b, id[:]) {
+		t.Errorf("not equal: %v and %v", b, id[:])
+	}
+
+	b[0] = 0xFF
+	if id.String() != string(encoded) {
+		t.Errorf("copy error, expected: %s, got: %s", string(encoded), id.String())
+	}
+}
+
+func TestUULID_ProtoBytes(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b := id.ProtoBytes()
+	if len(b) != uulid.BinarySize || !bytes.Equal(b, id[:]) {
+		t.Errorf("not equal: %v and %v", b, id[:])
+	}
+
+	id2, err := uulid.FromProtoBytes(b)
+	if err != nil || id2.Compare(id) != 0 {
+		t.Errorf("not equal: %s and %s, error: %v", id.String(), id2.String(), err)
+	}
+
+	for _, b := range [][]byte{nil, {}, b[:15], append(b, 0)} {
+		if _, err = uulid.FromProtoBytes(b); !errors.Is(err, uulid.ErrDataSize) {
+			t.Errorf("expected ErrDataSize for %v, got %v instead", b, err)
+		}
+	}
+}
+
+func TestUULID_StringAllocs(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// only the returned string is allocated
+	if allocs := testing.AllocsPerRun(100, func() { _ = id.String() }); allocs > 1 {
+		t.Errorf("expected at most 1 allocation, got: %f", allocs)
+	}
+}
+
+func TestUULID_FlagValue(t *testing.T) {
+	var id uulid.UULID
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&id, "id", "uulid")
+
+	if err := fs.Parse([]string{"-id", string(encoded)}); err != nil {
+		t.Error(err)
+	}
+
+	if id.String() != string(encoded) {
+		t.Errorf("flag error, expected: %s, got: %s", string(encoded), id.String())
+	}
+
+	fs.SetOutput(ioutil.Discard)
+	if err := fs.Parse([]string{"-id", "123456789090"}); err == nil {
+		t.Error("expected error for an invalid uulid flag")
+	}
+}
+
 func TestUULID_Entropy(t *testing.T) {
 	id, err := uulid.Parse(encoded)
 	if err != nil {
@@ -67,6 +273,76 @@ func TestUULID_Entropy(t *testing.T) {
 
 }
 
+func TestUULID_SetCounter(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	c := id
+	c.SetCounter(0xb3e7, 0x036d5b1b9f3cd753)
+	if c.Compare(id) != 0 {
+		t.Errorf("counter error, expected: %s, got: %s", id.String(), c.String())
+	}
+
+	c.SetCounter(0xFFFF, 1)
+	if expected := "0178a284-9eaf-ffff-0000-000000000001"; c.String() != expected {
+		t.Errorf("counter error, expected: %s, got: %s", expected, c.String())
+	}
+
+	if c.NodeID() != 0xFFFF || c.Counter() != 1 || c.Timestamp() != timestamp {
+		t.Errorf("counter error, expected: %d, %d and %d, got: %d, %d and %d",
+			0xFFFF, 1, timestamp, c.NodeID(), c.Counter(), c.Timestamp())
+	}
+}
+
+func TestUULID_EntropyTo(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf := make([]byte, 12)
+	if err = id.EntropyTo(buf); err != nil {
+		t.Error(err)
+	}
+
+	if hex.EncodeToString(buf[:10]) != entropy {
+		t.Errorf("entropy error, expected: %s, got: %x", entropy, buf[:10])
+	}
+
+	if err = id.EntropyTo(buf[:9]); err != uulid.ErrBufferSize {
+		t.Errorf("expected ErrBufferSize, got %s instead", err)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = id.EntropyTo(buf) }); n != 0 {
+		t.Errorf("allocs error, expected: 0, got: %v", n)
+	}
+}
+
+func TestUULID_TimeBytes(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	b := id.TimeBytes()
+	if hex.EncodeToString(b[:]) != "0178a2849eaf" {
+		t.Errorf("time bytes error, expected: %s, got: %x", "0178a2849eaf", b)
+	}
+
+	var id2 uulid.UULID
+	id2.SetTimeBytes(b)
+	if id2.Timestamp() != timestamp || id2.Counter() != 0 {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp, id2.Timestamp())
+	}
+
+	b[0] = 0xFF
+	if id.Timestamp() != timestamp {
+		t.Errorf("time bytes error, expected a copy, got: %x", id.TimeBytes())
+	}
+}
+
 func TestUULID_Compare(t *testing.T) {
 	id1, err := uulid.New()
 	if err != nil {
@@ -92,119 +368,1147 @@ func TestUULID_Compare(t *testing.T) {
 
 }
 
-func TestUULID_Marshaler(t *testing.T) {
+func TestUULID_Clone(t *testing.T) {
 	id, err := uulid.Parse(encoded)
 	if err != nil {
 		t.Error(err)
 	}
 
-	if v, err := id.Value(); err != nil || v.(string) != string(encoded) {
+	p := &id
+	c := p.Clone()
+	c[0] = 0xFF
+
+	if id.String() != string(encoded) {
+		t.Errorf("clone error, expected: %s, got: %s", string(encoded), id.String())
+	}
+
+	if c.Compare(id) == 0 {
+		t.Errorf("expected clone to differ: %s and %s", c.String(), id.String())
+	}
+}
+
+func TestUULID_IsZero(t *testing.T) {
+	var id uulid.UULID
+	if !id.IsZero() {
+		t.Errorf("expected zero value, got: %s", id.String())
+	}
+
+	id, err := uulid.Parse(encoded)
+	if err != nil {
 		t.Error(err)
 	}
 
-	if x, err := id.MarshalBinary(); err != nil || !bytes.Equal(x, id[:]) {
-		t.Errorf("not equal: %s and %s, error: %s", x, string(id[:]), err)
+	if id.IsZero() {
+		t.Errorf("expected non zero value, got: %s", id.String())
 	}
+}
 
-	if x, err := id.MarshalText(); err != nil || !bytes.Equal(x, encoded) {
-		t.Errorf("not equal: %s and %s, error: %s", x, encoded, err)
+func TestUULID_Equal(t *testing.T) {
+	id1, err := uulid.New()
+	if err != nil {
+		t.Error(err)
 	}
 
-	buf := make([]byte, 6)
-	if err = id.MarshalBinaryTo(buf); err != uulid.ErrBufferSize {
-		t.Errorf("expected ErrBufferSize, got: %s", err)
+	id2, err := uulid.New()
+	if err != nil {
+		t.Error(err)
 	}
 
-	if err = id.MarshalTextTo(buf); err != uulid.ErrBufferSize {
-		t.Errorf("expected ErrBufferSize, got: %s", err)
+	if !id1.Equal(id1) {
+		t.Errorf("expected equal: %s and %s", id1.String(), id1.String())
 	}
 
+	if id1.Equal(id2) {
+		t.Errorf("expected not equal: %s and %s", id1.String(), id2.String())
+	}
 }
 
-func TestUULID_Unmarshaler(t *testing.T) {
-	id, err := uulid.Parse(encoded)
+func TestUULID_EqualConstantTime(t *testing.T) {
+	id1, err := uulid.Parse(encoded)
 	if err != nil {
 		t.Error(err)
 	}
-	id2 := id
 
-	if err = id.Scan(encoded); err != nil || !bytes.Equal(id[:], id2[:]) {
-		t.Errorf("not equal: %s and %s, error: %s", id.String(), string(encoded), err)
+	if !id1.EqualConstantTime(id1.Clone()) {
+		t.Errorf("expected equal: %s and %s", id1.String(), id1.String())
 	}
 
-	var buf []byte
-	if buf, err = id.MarshalBinary(); err != nil {
-		t.Error(err)
+	// differing at the first, middle and last bytes
+	for _, i := range []int{0, 7, 15} {
+		id2 := id1
+		id2[i] ^= 1
+
+		if id1.EqualConstantTime(id2) || id2.EqualConstantTime(id1) {
+			t.Errorf("expected not equal: %s and %s", id1.String(), id2.String())
+		}
 	}
+}
 
-	if err = id.UnmarshalBinary(buf); err != nil {
+func TestUULID_BeforeAfter(t *testing.T) {
+	id1, err := uulid.Parse(encoded)
+	if err != nil {
 		t.Error(err)
 	}
 
-	if err = id.UnmarshalText(encoded); err != nil {
-		t.Error(err)
+	id2 := id1
+	id2[15] = 0
+	id3 := uulid.MinForTime(id1.Time().Add(time.Millisecond))
+
+	if id1.Before(id2) || id1.After(id2) || id2.Before(id1) || id2.After(id1) {
+		t.Errorf("expected same time for %s and %s", id1.String(), id2.String())
 	}
 
-	if err = id.UnmarshalBinary(encoded); err != uulid.ErrDataSize {
-		t.Error(err)
+	if !id1.Before(id3) || id1.After(id3) || !id3.After(id1) || id3.Before(id1) {
+		t.Errorf("expected %s before %s", id1.String(), id3.String())
 	}
 
-	if err = id.UnmarshalText([]byte(`123456789090`)); err != uulid.ErrDataSize {
-		t.Error(err)
+	if id1.CompareTime(id2) != 0 || id2.CompareTime(id1) != 0 || id1.Compare(id2) == 0 {
+		t.Errorf("compare time error, expected: 0 for %s and %s", id1.String(), id2.String())
+	}
+
+	if id1.CompareTime(id3) != -1 || id3.CompareTime(id1) != 1 {
+		t.Errorf("compare time error, expected %s before %s", id1.String(), id3.String())
+	}
+
+	min, max := uulid.MinForTime(id1.Time()), uulid.MaxForTime(id1.Time())
+	if !id1.Between(min, max) || !min.Between(min, max) || !max.Between(min, max) || !id1.Between(id1, id1) {
+		t.Errorf("expected %s between %s and %s", id1.String(), min.String(), max.String())
 	}
 
+	if id3.Between(min, max) || id1.Between(max, min) || id1.Between(id3, id3) {
+		t.Errorf("expected %s not between %s and %s", id3.String(), min.String(), max.String())
+	}
+
+	since := time.Since(id1.Time())
+	if age := id1.Age(); age < since {
+		t.Errorf("age error, expected at least: %s, got: %s", since, age)
+	}
 }
 
-func TestParse(t *testing.T) {
+func TestUULID_Marshaler(t *testing.T) {
 	id, err := uulid.Parse(encoded)
 	if err != nil {
 		t.Error(err)
 	}
 
-	if id.Compare(uulid.UULID{}) == 0 {
-		t.Error("non-initialized uulid")
+	if v, err := id.Value(); err != nil || v.(string) != string(encoded) {
+		t.Error(err)
 	}
 
-	if id.Timestamp() != timestamp {
-		t.Errorf("time parse error, expected: %d, got: %d", timestamp, id.Timestamp())
+	if x, err := id.MarshalBinary(); err != nil || !bytes.Equal(x, id[:]) {
+		t.Errorf("not equal: %s and %s, error: %s", x, string(id[:]), err)
 	}
 
-	if hex.EncodeToString(id.Entropy()) != entropy {
-		t.Errorf("time parse error, expected: %s, got: %s", entropy, hex.EncodeToString(id.Entropy()))
+	if x, err := id.MarshalText(); err != nil || !bytes.Equal(x, encoded) {
+		t.Errorf("not equal: %s and %s, error: %s", x, encoded, err)
 	}
-}
 
-func TestTimestamp(t *testing.T) {
-	tm := uulid.Time(timestamp)
-	ts := uulid.Timestamp(tm)
+	buf := make([]byte, 6)
+	if err = id.MarshalBinaryTo(buf); err != uulid.ErrBufferSize {
+		t.Errorf("expected ErrBufferSize, got: %s", err)
+	}
 
-	if ts != timestamp {
-		t.Errorf("time parse error, expected: %d, got: %d", timestamp, ts)
+	if err = id.MarshalTextTo(buf); err != uulid.ErrBufferSize {
+		t.Errorf("expected ErrBufferSize, got: %s", err)
 	}
+
 }
 
-func BenchmarkNew(b *testing.B) {
-	b.ReportAllocs()
-	b.SetBytes(uulid.BinarySize)
+func TestUULID_SameMillisecond(t *testing.T) {
+	now := uulid.Time(timestamp)
+	r := uulid.NewGeneratorWithSeed(1)
 
-	for i := 0; i < b.N; i++ {
-		_, _ = uulid.New()
+	ids := make([]uulid.UULID, 2)
+	if err := r.NewNAt(now, ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ids[0].SameMillisecond(ids[1]) || !ids[1].SameMillisecond(ids[0]) || ids[0].Compare(ids[1]) == 0 {
+		t.Errorf("expected same millisecond for %s and %s", ids[0].String(), ids[1].String())
+	}
+
+	later, err := r.NewAt(now.Add(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids[1].SameMillisecond(later) || later.SameMillisecond(ids[1]) {
+		t.Errorf("expected different milliseconds for %s and %s", ids[1].String(), later.String())
 	}
 }
 
-func BenchmarkParse(b *testing.B) {
-	id, err := uulid.New()
+func TestUULID_Appender(t *testing.T) {
+	id, err := uulid.Parse(encoded)
 	if err != nil {
-		b.Fatal(err)
+		t.Error(err)
 	}
 
-	buf := []byte(id.String())
+	prefix := []byte("id=")
+	buf := make([]byte, 0, 64)
 
-	b.ReportAllocs()
-	b.SetBytes(uulid.HexEncodedSize)
+	x, err := id.AppendText(append(buf, prefix...))
+	if err != nil || !bytes.Equal(x, append(prefix, encoded...)) {
+		t.Errorf("not equal: %s and %s%s, error: %s", x, prefix, encoded, err)
+	}
 
-	for i := 0; i < b.N; i++ {
-		_, _ = uulid.Parse(buf)
+	x, err = id.AppendBinary(append(buf, prefix...))
+	if err != nil || !bytes.Equal(x, append(prefix, id[:]...)) {
+		t.Errorf("not equal: %v and %v%v, error: %s", x, prefix, id[:], err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = id.AppendText(buf[:0])
+		_, _ = id.AppendBinary(buf[:0])
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got: %f", allocs)
+	}
+}
+
+func TestUULID_AppendToBuilder(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("id=")
+	id.AppendToBuilder(&sb)
+
+	if s := sb.String(); s != "id="+string(encoded) {
+		t.Errorf("not equal: %s and id=%s", s, encoded)
+	}
+
+	sb.Reset()
+	sb.Grow(uulid.HexEncodedSize * 100)
+	allocs := testing.AllocsPerRun(99, func() { id.AppendToBuilder(&sb) })
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got: %f", allocs)
+	}
+}
+
+func TestUULID_WriteTo(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var buf bytes.Buffer
+	n, err := id.WriteTo(&buf)
+	if err != nil || n != uulid.BinarySize || !bytes.Equal(buf.Bytes(), id[:]) {
+		t.Errorf("not equal: %v and %v, written: %d, error: %s", buf.Bytes(), id[:], n, err)
+	}
+
+	if _, err = id.WriteTo(errWriter{}); err != io.ErrShortWrite {
+		t.Errorf("expected io.ErrShortWrite, got: %s", err)
+	}
+}
+
+func TestUULID_ReadFrom(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	buf.Write(id[:])
+	buf.Write(id[:8])
+
+	var id2 uulid.UULID
+	n, err := id2.ReadFrom(buf)
+	if err != nil || n != uulid.BinarySize || id.Compare(id2) != 0 {
+		t.Errorf("not equal: %s and %s, read: %d, error: %s", id.String(), id2.String(), n, err)
+	}
+
+	var id3 uulid.UULID
+	if n, err = id3.ReadFrom(buf); err != io.ErrUnexpectedEOF || n != 8 || !id3.IsZero() {
+		t.Errorf("expected io.ErrUnexpectedEOF, got: %s, read: %d, id: %s", err, n, id3.String())
+	}
+
+	if _, err = id3.ReadFrom(buf); err != io.EOF {
+		t.Errorf("expected io.EOF, got: %s", err)
+	}
+}
+
+// errWriter always fails with io.ErrShortWrite.
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (n int, err error) { return 0, io.ErrShortWrite }
+
+func TestUULID_Unmarshaler(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+	id2 := id
+
+	if err = id.Scan(encoded); err != nil || !bytes.Equal(id[:], id2[:]) {
+		t.Errorf("not equal: %s and %s, error: %s", id.String(), string(encoded), err)
+	}
+
+	var buf []byte
+	if buf, err = id.MarshalBinary(); err != nil {
+		t.Error(err)
+	}
+
+	if err = id.UnmarshalBinary(buf); err != nil {
+		t.Error(err)
+	}
+
+	if err = id.UnmarshalText(encoded); err != nil {
+		t.Error(err)
+	}
+
+	if err = id.UnmarshalBinary(encoded); !errors.Is(err, uulid.ErrDataSize) {
+		t.Error(err)
+	}
+
+	if err = id.UnmarshalText([]byte(`123456789090`)); !errors.Is(err, uulid.ErrDataSize) {
+		t.Error(err)
+	}
+
+}
+
+func TestUULID_ScanBinary(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	var id2 uulid.UULID
+	if err = id2.Scan(id[:]); err != nil || id.Compare(id2) != 0 {
+		t.Errorf("not equal: %s and %s, error: %s", id.String(), id2.String(), err)
+	}
+
+	var id3 uulid.UULID
+	if err = id3.Scan([16]byte(id)); err != nil || id.Compare(id3) != 0 {
+		t.Errorf("not equal: %s and %s, error: %s", id.String(), id3.String(), err)
+	}
+
+	if err = id3.Scan(1); err != uulid.ErrInvalidType {
+		t.Errorf("expected ErrInvalidType, got: %s", err)
+	}
+}
+
+// valuer is a sql/driver.Valuer wrapping a value as returned by other packages.
+type valuer struct {
+	v   driver.Value
+	err error
+}
+
+func (v valuer) Value() (driver.Value, error) { return v.v, v.err }
+
+func TestUULID_ScanBase32(t *testing.T) {
+	var id uulid.UULID
+	if err := id.Scan("01F2H897NFPFKG6VAV3EFKSNTK"); err != nil {
+		t.Error(err)
+	}
+
+	if id.Timestamp() != timestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp, id.Timestamp())
+	}
+
+	if hex.EncodeToString(id.Entropy()) != entropy {
+		t.Errorf("entropy error, expected: %s, got: %x", entropy, id.Entropy())
+	}
+
+	var id2 uulid.UULID
+	if err := id2.Scan([]byte("01f2h897nfpfkg6vav3efksntk")); err != nil || id2.Compare(id) != 0 {
+		t.Errorf("not equal: %s and %s, error: %v", id, id2, err)
+	}
+
+	if err := id2.Scan("01F2H897NFPFKG6VAV3EFKSNTU"); !errors.Is(err, uulid.ErrInvalidChar) {
+		t.Errorf("expected ErrInvalidChar, got %v instead", err)
+	}
+}
+
+func TestUULID_ScanValuer(t *testing.T) {
+	var id uulid.UULID
+	if err := id.Scan(valuer{v: string(encoded)}); err != nil || id.String() != string(encoded) {
+		t.Errorf("scan error, expected: %s, got: %s, error: %v", encoded, id, err)
+	}
+
+	var id2 uulid.UULID
+	if err := id2.Scan(id); err != nil || id2.Compare(id) != 0 {
+		t.Errorf("not equal: %s and %s, error: %v", id, id2, err)
+	}
+
+	if err := id2.Scan(valuer{err: io.ErrUnexpectedEOF}); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+
+	if err := id2.Scan(valuer{v: valuer{v: string(encoded)}}); err != uulid.ErrInvalidType {
+		t.Errorf("expected ErrInvalidType, got: %v", err)
+	}
+}
+
+func TestUULID_GormDataType(t *testing.T) {
+	// a model with an UULID primary key as used by the gorm and bun ORMs
+	type model struct {
+		ID   uulid.UULID `gorm:"primaryKey" bun:",pk"`
+		Name string
+	}
+
+	m := model{ID: uulid.MustParse(encoded), Name: "name"}
+	if dt := m.ID.GormDataType(); dt != "uuid" {
+		t.Errorf("data type error, expected: uuid, got: %s", dt)
+	}
+
+	// create and read through the sql/driver interfaces
+	v, err := m.ID.Value()
+	if err != nil {
+		t.Error(err)
+	}
+
+	var read model
+	if err = read.ID.Scan(v); err != nil || read.ID.Compare(m.ID) != 0 {
+		t.Errorf("not equal: %s and %s, error: %v", read.ID.String(), m.ID.String(), err)
+	}
+}
+
+func TestBinaryUULID(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	bid := uulid.BinaryUULID(id)
+	v, err := bid.Value()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, id[:]) {
+		t.Errorf("not equal: %v and %v", v, id[:])
+	}
+
+	var bid2 uulid.BinaryUULID
+	if err = bid2.Scan(v); err != nil || uulid.UULID(bid2).Compare(id) != 0 {
+		t.Errorf("not equal: %v and %v, error: %s", bid2, id, err)
+	}
+
+	var bid3 uulid.BinaryUULID
+	if err = bid3.Scan(encoded); err != nil || uulid.UULID(bid3).Compare(id) != 0 {
+		t.Errorf("not equal: %v and %v, error: %s", bid3, id, err)
+	}
+}
+
+func TestUULID_Gob(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	x, err := id.GobEncode()
+	if err != nil || !bytes.Equal(x, id[:]) {
+		t.Errorf("not equal: %v and %v, error: %s", x, id[:], err)
+	}
+
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(id); err != nil {
+		t.Error(err)
+	}
+
+	var id2 uulid.UULID
+	if err = gob.NewDecoder(&buf).Decode(&id2); err != nil || id.Compare(id2) != 0 {
+		t.Errorf("not equal: %s and %s, error: %s", id.String(), id2.String(), err)
+	}
+
+	if err = id2.GobDecode(encoded); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got: %s", err)
+	}
+}
+
+func TestFromUUID(t *testing.T) {
+	// uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479") from github.com/google/uuid
+	u := [16]byte{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}
+
+	id := uulid.FromUUID(u)
+	if id.String() != "f47ac10b-58cc-4372-a567-0e02b2c3d479" {
+		t.Errorf("conversion error, expected: %s, got: %s", "f47ac10b-58cc-4372-a567-0e02b2c3d479", id.String())
+	}
+
+	if id.ToArray() != u {
+		t.Errorf("conversion error, expected: %v, got: %v", u, id.ToArray())
+	}
+}
+
+func TestParse(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id.IsZero() {
+		t.Error("non-initialized uulid")
+	}
+
+	if id.Timestamp() != timestamp {
+		t.Errorf("time parse error, expected: %d, got: %d", timestamp, id.Timestamp())
+	}
+
+	if hex.EncodeToString(id.Entropy()) != entropy {
+		t.Errorf("time parse error, expected: %s, got: %s", entropy, hex.EncodeToString(id.Entropy()))
+	}
+}
+
+func TestParseTo(t *testing.T) {
+	var v struct {
+		ID uulid.UULID
+	}
+
+	if err := uulid.ParseTo(encoded, &v.ID); err != nil {
+		t.Error(err)
+	}
+
+	if v.ID.String() != string(encoded) {
+		t.Errorf("parse error, expected: %s, got: %s", encoded, v.ID.String())
+	}
+
+	if err := uulid.ParseTo(encoded[:35], &v.ID); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	if !uulid.Min.IsZero() || uulid.Min.Compare(uulid.UULID{}) != 0 {
+		t.Errorf("min error, expected: %s, got: %s", uulid.UULID{}, uulid.Min)
+	}
+
+	if uulid.Max.Timestamp() != uulid.MaxTimestamp {
+		t.Errorf("max error, expected timestamp: %d, got: %d", uint64(uulid.MaxTimestamp), uulid.Max.Timestamp())
+	}
+
+	if s := uulid.Max.String(); s != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("max error, expected: %s, got: %s", "ffffffff-ffff-ffff-ffff-ffffffffffff", s)
+	}
+
+	if id, err := uulid.ParseString(uulid.Max.String()); err != nil || id.Compare(uulid.Max) != 0 {
+		t.Errorf("max round trip error, expected: %s, got: %s, error: %v", uulid.Max, id, err)
+	}
+
+	if next := uulid.NextAfter(uulid.Max); next.Compare(uulid.Max) != 0 {
+		t.Errorf("max error, expected NextAfter to saturate at: %s, got: %s", uulid.Max, next)
+	}
+}
+
+func TestFromName(t *testing.T) {
+	// the RFC 4122 DNS namespace
+	ns := uulid.MustParseString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	expected := "886313e1-3b8a-5372-9b90-0c9aee199e5d"
+
+	for i := 0; i < 2; i++ {
+		if id := uulid.FromName(ns, []byte("python.org")); id.String() != expected || id.UUIDVersion() != 5 {
+			t.Errorf("name error, expected: %s, got: %s", expected, id.String())
+		}
+	}
+
+	if id := uulid.FromName(ns, []byte("golang.org")); id.String() == expected {
+		t.Errorf("name error, expected different uulids for different names, got: %s", id.String())
+	}
+
+	if id := uulid.FromName(uulid.UULID{}, []byte("python.org")); id.String() == expected {
+		t.Errorf("name error, expected different uulids for different namespaces, got: %s", id.String())
+	}
+}
+
+func TestFromTimestamp(t *testing.T) {
+	id, err := uulid.FromTimestamp(timestamp)
+	if err != nil || id.Timestamp() != timestamp || id.Counter() != 0 || id.NodeID() != 0 {
+		t.Errorf("timestamp error, expected: %d with zero entropy, got: %s, error: %v", timestamp, id, err)
+	}
+
+	if _, err = uulid.FromTimestamp(uulid.MaxTimestamp + 1); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %v instead", err)
+	}
+}
+
+func TestFromParts(t *testing.T) {
+	e, err := hex.DecodeString(entropy)
+	if err != nil {
+		t.Error(err)
+	}
+
+	id, err := uulid.FromParts(uulid.Time(timestamp), e)
+	if err != nil || id.String() != string(encoded) {
+		t.Errorf("parts error, expected: %s, got: %s, error: %v", encoded, id.String(), err)
+	}
+
+	if _, err = uulid.FromParts(uulid.MaxTime().Add(time.Millisecond), e); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %v instead", err)
+	}
+
+	if _, err = uulid.FromParts(time.Time{}, e); err != uulid.ErrSmallTime {
+		t.Errorf("expected ErrSmallTime, got %v instead", err)
+	}
+
+	for _, e := range [][]byte{nil, e[:9], append(e, 0)} {
+		if id, err = uulid.FromParts(uulid.Time(timestamp), e); !errors.Is(err, uulid.ErrDataSize) || !id.IsZero() {
+			t.Errorf("expected ErrDataSize and zero uulid, got: %s, error: %v", id.String(), err)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	for _, s := range []string{
+		string(encoded),
+		"0178a2849eafb3e7036d5b1b9f3cd753",
+		"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753}",
+		"urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+	} {
+		id, err := uulid.ParseString(s)
+		if err != nil || id.String() != string(encoded) {
+			t.Errorf("parse error for %s, expected: %s, got: %s, error: %v", s, encoded, id, err)
+		}
+	}
+
+	if _, err := uulid.ParseString(string(encoded) + string(encoded)); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	s := string(encoded)
+	if n := testing.AllocsPerRun(100, func() { _, _ = uulid.ParseString(s) }); n != 0 {
+		t.Errorf("allocs error, expected: 0, got: %v", n)
+	}
+}
+
+func TestParse_UUIDv7(t *testing.T) {
+	// RFC 9562 appendix A.6 UUIDv7 test vector
+	id, err := uulid.Parse([]byte("017F22E2-79B0-7CC3-98C4-DC0C0C07398F"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !id.IsUUIDv7() || id.UUIDVersion() != 7 {
+		t.Errorf("version error, expected UUIDv7, got: %s", id)
+	}
+
+	if expected := uint64(0x017F22E279B0); id.Timestamp() != expected {
+		t.Errorf("timestamp error, expected: %d, got: %d", expected, id.Timestamp())
+	}
+}
+
+func TestParse_Formats(t *testing.T) {
+	valid := []string{
+		"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753}",
+		"urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+		"URN:UUID:0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+		"0178a2849eafb3e7036d5b1b9f3cd753",
+	}
+
+	for _, s := range valid {
+		id, err := uulid.Parse([]byte(s))
+		if err != nil || id.String() != string(encoded) {
+			t.Errorf("parse error for %s, expected: %s, got: %s, error: %s", s, string(encoded), id.String(), err)
+		}
+	}
+
+	invalid := []string{
+		"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+		"0178a284-9eaf-b3e7-036d-5b1b9f3cd753}",
+		"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753)",
+		"(0178a284-9eaf-b3e7-036d-5b1b9f3cd753}",
+		"urn:uid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+		"urn:uuid:{0178a284-9eaf-b3e7-036d-5b1b9f3cd753}",
+	}
+
+	for _, s := range invalid {
+		if _, err := uulid.Parse([]byte(s)); !errors.Is(err, uulid.ErrDataSize) {
+			t.Errorf("expected ErrDataSize for %s, got: %s", s, err)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	if !uulid.Valid(encoded) || !uulid.ValidString(string(encoded)) {
+		t.Errorf("expected valid: %s", string(encoded))
+	}
+
+	invalid := []string{
+		"",
+		"123456789090",
+		"0178a284-9eaf-b3e7-036d-5b1b9f3cd75g",
+		"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753",
+	}
+
+	for _, s := range invalid {
+		if uulid.Valid([]byte(s)) || uulid.ValidString(s) {
+			t.Errorf("expected invalid: %s", s)
+		}
+	}
+}
+
+func TestParse_Separators(t *testing.T) {
+	r := uulid.NewGeneratorWithSeed(1)
+
+	// the table decoding of the dashed format and the fallback decoding
+	// of other separators must agree, regardless of the letter case
+	for i := 0; i < 1024; i++ {
+		id, err := r.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range []string{
+			id.String(),
+			id.StringUpper(),
+			strings.Replace(id.String(), "-", " ", -1),
+		} {
+			if parsed, err := uulid.ParseString(s); err != nil || parsed.Compare(id) != 0 {
+				t.Fatalf("parse error for %s, expected: %s, got: %s, error: %v", s, id, parsed, err)
+			}
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		data string
+		len  int
+		pos  int
+	}{
+		{"0178a284-9eaf-b3e7-036d-5b1b9f3cd75z", 36, 35},
+		{"0178a284-9eaf-b3e7-03xd-5b1b9f3cd753", 36, 21},
+		{"0178a2849eafb3e7036d5b1b9f3cd75z", 32, 31},
+		{"{0178a284-9eaf-b3e7-036d-5b1b9f3cd75z}", 38, 36},
+		{"urn:uuid:z178a284-9eaf-b3e7-036d-5b1b9f3cd753", 45, 9},
+		{"123456789090", 12, -1},
+	}
+
+	for _, test := range tests {
+		_, err := uulid.Parse([]byte(test.data))
+
+		var perr *uulid.ParseError
+		if !errors.As(err, &perr) || perr.Len != test.len || perr.Pos != test.pos {
+			t.Errorf("parse error for %s, expected length %d and position %d, got: %v", test.data, test.len, test.pos, err)
+		}
+	}
+
+	// malformed hex characters within a valid length
+	for _, test := range tests[:5] {
+		if valid := uulid.ValidString(test.data); valid {
+			t.Errorf("expected invalid uulid: %s", test.data)
+		}
+
+		if _, err := uulid.Parse([]byte(test.data)); !errors.Is(err, uulid.ErrInvalidChar) {
+			t.Errorf("expected ErrInvalidChar for %s, got %v instead", test.data, err)
+		}
+	}
+
+	if _, err := uulid.ParseString("0178a284-9eaf-b3e7-036d-5b1b9f3cd75Z"); !errors.Is(err, uulid.ErrInvalidChar) {
+		t.Errorf("expected ErrInvalidChar, got %v instead", err)
+	}
+
+	_, err := uulid.Parse([]byte("123456789090"))
+	if !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	if expected := "uulid: bad data size when parsing (length 12)"; err.Error() != expected {
+		t.Errorf("error message, expected: %s, got: %s", expected, err)
+	}
+
+	_, err = uulid.ParseBase32([]byte("01F2H897NFPFKG6VAV3EFKSNTU"))
+	if expected := "uulid: invalid character when parsing (length 26, position 25)"; err == nil || err.Error() != expected {
+		t.Errorf("error message, expected: %s, got: %v", expected, err)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	id, err := uulid.ParseStrict(encoded)
+	if err != nil || id.String() != string(encoded) {
+		t.Errorf("parse error, expected: %s, got: %s, error: %s", string(encoded), id.String(), err)
+	}
+
+	invalid := []string{
+		"0178A284-9EAF-B3E7-036D-5B1B9F3CD753", // uppercase
+		"0178a284-9eaf-b3e7-036D-5b1b9f3cd753", // mixed case
+		"0178a2849-eaf-b3e7-036d-5b1b9f3cd753", // misplaced dash
+		"0178a284-9eaf-b3e7-036d+5b1b9f3cd753", // invalid separator
+		"0178a284-9eaf-b3e7-036d-5b1b9f3cd75g", // invalid hex
+	}
+
+	for _, s := range invalid {
+		if _, err = uulid.ParseStrict([]byte(s)); !errors.Is(err, uulid.ErrInvalidFormat) {
+			t.Errorf("expected ErrInvalidFormat for %s, got: %s", s, err)
+		}
+	}
+
+	invalid = []string{
+		"{0178a284-9eaf-b3e7-036d-5b1b9f3cd753}",        // braced
+		"urn:uuid:0178a284-9eaf-b3e7-036d-5b1b9f3cd753", // urn
+		"0178a2849eafb3e7036d5b1b9f3cd753",              // no dashes
+	}
+
+	for _, s := range invalid {
+		if _, err = uulid.ParseStrict([]byte(s)); !errors.Is(err, uulid.ErrDataSize) {
+			t.Errorf("expected ErrDataSize for %s, got: %s", s, err)
+		}
+	}
+}
+
+func TestMustNew(t *testing.T) {
+	if id := uulid.MustNew(); id.IsZero() {
+		t.Error("non-initialized uulid")
+	}
+}
+
+func TestNewString(t *testing.T) {
+	s, err := uulid.NewString()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err = uulid.ParseStrict([]byte(s)); err != nil {
+		t.Errorf("parse error for %s: %s", s, err)
+	}
+
+	if s = uulid.MustNewString(); !uulid.ValidString(s) {
+		t.Errorf("invalid uulid string: %s", s)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _, _ = uulid.NewString() }); n != 1 {
+		t.Errorf("allocs error, expected: 1, got: %v", n)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if id := uulid.MustParse(encoded); id.String() != string(encoded) {
+		t.Errorf("parse error, expected: %s, got: %s", string(encoded), id.String())
+	}
+
+	if id := uulid.MustParseString(string(encoded)); id.String() != string(encoded) {
+		t.Errorf("parse error, expected: %s, got: %s", string(encoded), id.String())
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, uulid.ErrDataSize) {
+			t.Errorf("expected panic with ErrDataSize, got: %v", err)
+		}
+	}()
+
+	uulid.MustParseString("123456789090")
+}
+
+func TestTimestamp(t *testing.T) {
+	tm := uulid.Time(timestamp)
+	ts := uulid.Timestamp(tm)
+
+	if ts != timestamp {
+		t.Errorf("time parse error, expected: %d, got: %d", timestamp, ts)
+	}
+}
+
+func TestTimestamp_UnixMilli(t *testing.T) {
+	tm := time.Unix(1617634303, 663999999)
+	if ts := uulid.Timestamp(tm); ts != timestamp || ts != uint64(tm.UnixMilli()) {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp, ts)
+	}
+
+	// sub millisecond nanoseconds are truncated at the boundaries
+	for _, ns := range []int64{0, 1, 999999, 1000000} {
+		tm := time.Unix(1617634303, 663000000+ns)
+		if ts := uulid.Timestamp(tm); ts != uint64(tm.UnixMilli()) {
+			t.Errorf("timestamp error, expected: %d, got: %d", tm.UnixMilli(), ts)
+		}
+	}
+
+	if tm := uulid.Time(timestamp); !tm.Equal(time.UnixMilli(int64(timestamp))) {
+		t.Errorf("time error, expected: %s, got: %s", time.UnixMilli(int64(timestamp)), tm)
+	}
+}
+
+func TestTimestamp_MaxTimestamp(t *testing.T) {
+	if ts := uulid.Timestamp(uulid.MaxTime()); ts != uulid.MaxTimestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), ts)
+	}
+
+	if ts := uulid.Timestamp(uulid.MaxTime().Add(999 * time.Microsecond)); ts != uulid.MaxTimestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), ts)
+	}
+
+	if ts := uulid.Timestamp(uulid.MaxTime().Add(time.Millisecond)); ts != uulid.MaxTimestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), ts)
+	}
+
+	// times that would wrap around the uint64 milliseconds
+	if ts := uulid.Timestamp(time.Unix(1<<62, 0)); ts != uulid.MaxTimestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), ts)
+	}
+
+	if tm := uulid.Time(uulid.MaxTimestamp + 1); !tm.Equal(uulid.MaxTime()) {
+		t.Errorf("time error, expected: %s, got: %s", uulid.MaxTime(), tm)
+	}
+
+	var id uulid.UULID
+	if err := id.SetTime(uulid.MaxTime()); err != nil {
+		t.Error(err)
+	}
+
+	if err := id.SetTime(uulid.MaxTime().Add(time.Millisecond)); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %s instead", err)
+	}
+
+	if err := id.SetTime(time.Unix(1<<62, 0)); err != uulid.ErrBigTime {
+		t.Errorf("expected ErrBigTime, got %s instead", err)
+	}
+}
+
+func TestTimestamp_PreEpoch(t *testing.T) {
+	for _, tm := range []time.Time{
+		{},
+		time.Date(1969, 7, 20, 20, 17, 0, 0, time.UTC),
+		time.Unix(0, 0).Add(-time.Millisecond),
+	} {
+		if ts := uulid.Timestamp(tm); ts != 0 {
+			t.Errorf("timestamp error for %s, expected: 0, got: %d", tm, ts)
+		}
+
+		var id uulid.UULID
+		if err := id.SetTime(tm); err != uulid.ErrSmallTime {
+			t.Errorf("expected ErrSmallTime for %s, got %v instead", tm, err)
+		}
+
+		if id := uulid.MinForTime(tm); !id.IsZero() {
+			t.Errorf("min error for %s, expected zero uulid, got: %s", tm, id)
+		}
+
+		r := uulid.NewGeneratorWithSeed(1)
+		if _, err := r.NewAt(tm); err != uulid.ErrSmallTime {
+			t.Errorf("expected ErrSmallTime for %s, got %v instead", tm, err)
+		}
+	}
+
+	if ts := uulid.Timestamp(time.Unix(0, 0)); ts != 0 {
+		t.Errorf("timestamp error, expected: 0, got: %d", ts)
+	}
+}
+
+func TestNextAfter(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if next := uulid.NextAfter(id); next.String() != "0178a284-9eaf-b3e7-036d-5b1b9f3cd754" || id.Compare(next) != -1 {
+		t.Errorf("next error, expected: %s, got: %s", "0178a284-9eaf-b3e7-036d-5b1b9f3cd754", next.String())
+	}
+
+	// carries over the entropy into the timestamp
+	max := uulid.MaxForTime(id.Time())
+	if next := uulid.NextAfter(max); next.Compare(uulid.MinForTime(id.Time().Add(time.Millisecond))) != 0 {
+		t.Errorf("next error, expected: %s, got: %s", uulid.MinForTime(id.Time().Add(time.Millisecond)), next.String())
+	}
+
+	// saturates at the largest uulid
+	var last uulid.UULID
+	for i := range last {
+		last[i] = 0xFF
+	}
+
+	if next := uulid.NextAfter(last); next.Compare(last) != 0 {
+		t.Errorf("next error, expected: %s, got: %s", last.String(), next.String())
+	}
+}
+
+func TestPrevBefore(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if prev := uulid.PrevBefore(id); prev.String() != "0178a284-9eaf-b3e7-036d-5b1b9f3cd752" || id.Compare(prev) != 1 {
+		t.Errorf("prev error, expected: %s, got: %s", "0178a284-9eaf-b3e7-036d-5b1b9f3cd752", prev.String())
+	}
+
+	// borrows across byte boundaries
+	id = uulid.MustParseString("0178a284-9eaf-b3e7-036d-5b1b9f3c0000")
+	if prev := uulid.PrevBefore(id); prev.String() != "0178a284-9eaf-b3e7-036d-5b1b9f3bffff" {
+		t.Errorf("prev error, expected: %s, got: %s", "0178a284-9eaf-b3e7-036d-5b1b9f3bffff", prev.String())
+	}
+
+	// borrows the timestamp into the entropy
+	min := uulid.MinForTime(id.Time())
+	if prev := uulid.PrevBefore(min); prev.Compare(uulid.MaxForTime(id.Time().Add(-time.Millisecond))) != 0 {
+		t.Errorf("prev error, expected: %s, got: %s", uulid.MaxForTime(id.Time().Add(-time.Millisecond)), prev.String())
+	}
+
+	if prev := uulid.PrevBefore(uulid.NextAfter(id)); prev.Compare(id) != 0 {
+		t.Errorf("prev error, expected: %s, got: %s", id.String(), prev.String())
+	}
+
+	// saturates at the zero uulid
+	if prev := uulid.PrevBefore(uulid.Min); !prev.IsZero() {
+		t.Errorf("prev error, expected: %s, got: %s", uulid.Min.String(), prev.String())
+	}
+}
+
+func TestEpochTimestamp(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 500*int(time.Millisecond), time.UTC)
+	tm := uulid.Time(timestamp)
+
+	ms := uulid.EpochTimestamp(tm, epoch)
+	if ms != timestamp-uulid.Timestamp(epoch) {
+		t.Errorf("timestamp error, expected: %d, got: %d", timestamp-uulid.Timestamp(epoch), ms)
+	}
+
+	if et := uulid.EpochTime(ms, epoch); !et.Equal(tm) {
+		t.Errorf("time error, expected: %s, got: %s", tm, et)
+	}
+
+	if ms = uulid.EpochTimestamp(epoch.Add(-time.Millisecond), epoch); ms != 0 {
+		t.Errorf("timestamp error, expected: %d, got: %d", 0, ms)
+	}
+
+	max := uulid.EpochTime(uulid.MaxTimestamp, epoch)
+	if ms = uulid.EpochTimestamp(max, epoch); ms != uulid.MaxTimestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), ms)
+	}
+
+	if ms = uulid.EpochTimestamp(max.Add(time.Millisecond), epoch); ms != uulid.MaxTimestamp {
+		t.Errorf("timestamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), ms)
+	}
+}
+
+func TestForTime(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	min := uulid.MinForTime(id.Time())
+	max := uulid.MaxForTime(id.Time())
+
+	if min.String() != "0178a284-9eaf-0000-0000-000000000000" {
+		t.Errorf("min error, expected: %s, got: %s", "0178a284-9eaf-0000-0000-000000000000", min.String())
+	}
+
+	if max.String() != "0178a284-9eaf-ffff-ffff-ffffffffffff" {
+		t.Errorf("max error, expected: %s, got: %s", "0178a284-9eaf-ffff-ffff-ffffffffffff", max.String())
+	}
+
+	if min.Compare(id) != -1 || max.Compare(id) != 1 {
+		t.Errorf("range error, expected %s between %s and %s", id.String(), min.String(), max.String())
+	}
+
+	if min = uulid.MinForTime(uulid.MaxTime().Add(time.Hour)); min.Timestamp() != uulid.MaxTimestamp {
+		t.Errorf("clamp error, expected: %d, got: %d", uint64(uulid.MaxTimestamp), min.Timestamp())
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(uulid.BinarySize)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = uulid.New()
+	}
+}
+
+func BenchmarkUULID_String(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_ = id.String()
+	}
+}
+
+func BenchmarkUULID_AppendText(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := make([]byte, 0, uulid.HexEncodedSize)
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = id.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkUULID_AppendToBuilder(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var sb strings.Builder
+	sb.Grow(uulid.HexEncodedSize)
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		// reuse the builder buffer, as the builder does not allow truncating it
+		if sb.Len() >= 1<<20 {
+			sb.Reset()
+			sb.Grow(1 << 20)
+		}
+		id.AppendToBuilder(&sb)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := []byte(id.String())
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = uulid.Parse(buf)
+	}
+}
+
+func BenchmarkParse_Fallback(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// the non dash separators use the encoding/hex decoding
+	buf := bytes.Replace([]byte(id.String()), []byte("-"), []byte(" "), -1)
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = uulid.Parse(buf)
+	}
+}
+
+func BenchmarkParseTo(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := []byte(id.String())
+	dst := make([]struct {
+		ID   uulid.UULID
+		Name string
+	}, 1024)
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_ = uulid.ParseTo(buf, &dst[i%len(dst)].ID)
 	}
 }
 
@@ -218,3 +1522,19 @@ func BenchmarkNewConcurrent(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkUULID_ScanString(b *testing.B) {
+	id, err := uulid.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var src interface{} = id.String()
+
+	b.ReportAllocs()
+	b.SetBytes(uulid.HexEncodedSize)
+
+	for i := 0; i < b.N; i++ {
+		_ = id.Scan(src)
+	}
+}
@@ -0,0 +1,31 @@
+package uulid
+
+import "encoding/xml"
+
+// MarshalXML implements the xml.Marshaler interface.
+// The UULID is encoded as the canonical text form within the element.
+func (id UULID) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	return e.EncodeElement(id.String(), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+// A *ParseError wrapping ErrDataSize is returned for empty or self-closing elements.
+func (id *UULID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	var s string
+	if err = d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface.
+// The UULID is encoded as the canonical text form within the attribute.
+func (id UULID) MarshalXMLAttr(name xml.Name) (attr xml.Attr, err error) {
+	return xml.Attr{Name: name, Value: id.String()}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+// A *ParseError wrapping ErrDataSize is returned for empty attributes.
+func (id *UULID) UnmarshalXMLAttr(attr xml.Attr) (err error) {
+	return id.UnmarshalText([]byte(attr.Value))
+}
@@ -0,0 +1,52 @@
+package uulid_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/brunotm/uulid"
+)
+
+type xmlDocument struct {
+	XMLName xml.Name    `xml:"document"`
+	Ref     uulid.UULID `xml:"ref,attr"`
+	ID      uulid.UULID `xml:"id"`
+}
+
+func TestUULID_XML(t *testing.T) {
+	id, err := uulid.Parse(encoded)
+	if err != nil {
+		t.Error(err)
+	}
+
+	doc := xmlDocument{Ref: id, ID: id}
+	expected := `<document ref="0178a284-9eaf-b3e7-036d-5b1b9f3cd753"><id>0178a284-9eaf-b3e7-036d-5b1b9f3cd753</id></document>`
+
+	data, err := xml.Marshal(doc)
+	if err != nil || string(data) != expected {
+		t.Errorf("marshal error, expected: %s, got: %s, error: %v", expected, data, err)
+	}
+
+	var parsed xmlDocument
+	if err = xml.Unmarshal(data, &parsed); err != nil {
+		t.Error(err)
+	}
+
+	if parsed.Ref.Compare(id) != 0 || parsed.ID.Compare(id) != 0 {
+		t.Errorf("unmarshal error, expected: %s, got: %s and %s", id, parsed.Ref, parsed.ID)
+	}
+}
+
+func TestUULID_XMLErrors(t *testing.T) {
+	for _, data := range []string{
+		`<document ref="0178a284-9eaf-b3e7-036d-5b1b9f3cd753"><id/></document>`,
+		`<document ref="0178a284-9eaf-b3e7-036d-5b1b9f3cd753"><id></id></document>`,
+		`<document ref=""><id>0178a284-9eaf-b3e7-036d-5b1b9f3cd753</id></document>`,
+	} {
+		var doc xmlDocument
+		if err := xml.Unmarshal([]byte(data), &doc); !errors.Is(err, uulid.ErrDataSize) {
+			t.Errorf("expected ErrDataSize for %s, got %v instead", data, err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/brunotm/uulid/yaml
+
+go 1.17
+
+require (
+	github.com/brunotm/uulid v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/brunotm/uulid => ../
@@ -0,0 +1,43 @@
+// Package yaml provides YAML support for UULIDs
+// using the gopkg.in/yaml.v3 package.
+//
+// It is a separate module, so the uulid module remains free of dependencies.
+package yaml
+
+import (
+	"github.com/brunotm/uulid"
+	"gopkg.in/yaml.v3"
+)
+
+// UULID is an uulid.UULID that is YAML encoded as its canonical 36 characters
+// text form by implementing the yaml.Marshaler and yaml.Unmarshaler interfaces.
+//
+// As with the yaml.v3 decoding of other types, the null scalar leaves the UULID
+// unchanged, so a null field decoded into a new value remains the zero UULID.
+type UULID uulid.UULID
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (id UULID) MarshalYAML() (v interface{}, err error) {
+	return uulid.UULID(id).String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// Besides the canonical form it accepts the text forms supported by uulid.Parse.
+func (id *UULID) UnmarshalYAML(value *yaml.Node) (err error) {
+	if value.Kind == yaml.ScalarNode && value.ShortTag() == "!!null" {
+		return nil
+	}
+
+	var s string
+	if err = value.Decode(&s); err != nil {
+		return err
+	}
+
+	u, err := uulid.ParseString(s)
+	if err != nil {
+		return err
+	}
+
+	*id = UULID(u)
+	return nil
+}
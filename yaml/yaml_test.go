@@ -0,0 +1,63 @@
+package yaml_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/brunotm/uulid"
+	uulidyaml "github.com/brunotm/uulid/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+var encoded = "0178a284-9eaf-b3e7-036d-5b1b9f3cd753"
+
+type document struct {
+	ID uulidyaml.UULID `yaml:"id"`
+}
+
+func TestUULID_YAML(t *testing.T) {
+	id := uulidyaml.UULID(uulid.MustParseString(encoded))
+	expected := "id: " + encoded + "\n"
+
+	data, err := yaml.Marshal(document{ID: id})
+	if err != nil || string(data) != expected {
+		t.Errorf("marshal error, expected: %s, got: %s, error: %v", expected, data, err)
+	}
+
+	var doc document
+	if err = yaml.Unmarshal(data, &doc); err != nil || doc.ID != id {
+		t.Errorf("unmarshal error, expected: %s, got: %s, error: %v", uulid.UULID(id), uulid.UULID(doc.ID), err)
+	}
+}
+
+func TestUULID_YAMLNull(t *testing.T) {
+	id := uulidyaml.UULID(uulid.MustParseString(encoded))
+
+	for _, data := range []string{"id: null\n", "id: ~\n", "id:\n"} {
+		var doc document
+		if err := yaml.Unmarshal([]byte(data), &doc); err != nil || !uulid.UULID(doc.ID).IsZero() {
+			t.Errorf("unmarshal error for %q, expected zero uulid, got: %s, error: %v", data, uulid.UULID(doc.ID), err)
+		}
+
+		// null leaves the uulid unchanged
+		doc.ID = id
+		if err := yaml.Unmarshal([]byte(data), &doc); err != nil || doc.ID != id {
+			t.Errorf("unmarshal error for %q, expected: %s, got: %s, error: %v", data, uulid.UULID(id), uulid.UULID(doc.ID), err)
+		}
+	}
+}
+
+func TestUULID_YAMLErrors(t *testing.T) {
+	var doc document
+	if err := yaml.Unmarshal([]byte("id: 123456789090\n"), &doc); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	if err := yaml.Unmarshal([]byte("id: \"\"\n"), &doc); !errors.Is(err, uulid.ErrDataSize) {
+		t.Errorf("expected ErrDataSize, got %v instead", err)
+	}
+
+	if err := yaml.Unmarshal([]byte("id: [1, 2]\n"), &doc); err == nil {
+		t.Error("expected error for a sequence node")
+	}
+}